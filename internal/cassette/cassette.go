@@ -0,0 +1,290 @@
+// Package cassette records HTTP interactions with a controller to fixture
+// files and replays them, so client tests can run against real API
+// responses without a controller.
+//
+// Recorded requests and responses are scrubbed before they are written:
+// credentials are never stored, secret JSON fields are masked, and the
+// recorded host is replaced with a placeholder.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Mode selects whether a Recorder talks to a real server.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette and fails requests that
+	// were not recorded.
+	ModeReplay Mode = iota
+	// ModeRecord forwards requests to the real server and records them.
+	ModeRecord
+)
+
+// Host replaces the recorded server's scheme and host in fixtures.
+const Host = "https://aap.example.com"
+
+// Masked replaces secret values in fixtures.
+const Masked = "***"
+
+// Request is the recorded part of an HTTP request.
+type Request struct {
+	Method string `json:"method"`
+	// Path includes the query string.
+	Path string          `json:"path"`
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// Response is the recorded part of an HTTP response.
+type Response struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+// Interaction is one request and the response it received.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// recordedHeaders are the response headers kept in fixtures.
+var recordedHeaders = []string{"Content-Type", "Retry-After", "X-API-Request-Id", "X-Request-Id"}
+
+// secretKeys are JSON fields, lower-cased, whose values are masked. Keys
+// containing "password" or "secret" are always masked.
+var secretKeys = map[string]bool{
+	"ssh_key_data":   true,
+	"ssh_key_unlock": true,
+	"token":          true,
+	"security_token": true,
+}
+
+// Recorder is an http.RoundTripper that records or replays interactions.
+type Recorder struct {
+	mode Mode
+	path string
+	real http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// New returns a Recorder for the cassette file at path. In ModeReplay the
+// file must exist; in ModeRecord requests are sent with real, or
+// http.DefaultTransport if real is nil, and written to path by Stop.
+func New(path string, mode Mode, real http.RoundTripper) (*Recorder, error) {
+	r := &Recorder{mode: mode, path: path, real: real}
+	if r.real == nil {
+		r.real = http.DefaultTransport
+	}
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette: %w", err)
+	}
+	if err := json.Unmarshal(b, &r.interactions); err != nil {
+		return nil, fmt.Errorf("decoding cassette %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.interactions))
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	recorded := Request{
+		Method: req.Method,
+		Path:   req.URL.RequestURI(),
+		Body:   scrubJSON(body, ""),
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	resp, err := r.real.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	origin := req.URL.Scheme + "://" + req.URL.Host
+	i := Interaction{
+		Request: recorded,
+		Response: Response{
+			Status: resp.StatusCode,
+			Body:   scrubJSON(respBody, origin),
+		},
+	}
+	for _, h := range recordedHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			if i.Response.Headers == nil {
+				i.Response.Headers = map[string]string{}
+			}
+			i.Response.Headers[h] = v
+		}
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, i)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// replay returns the first unused interaction matching req, so that
+// repeated identical requests are answered in recorded order.
+func (r *Recorder) replay(req *http.Request, want Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for n, i := range r.interactions {
+		if r.used[n] || !matches(i.Request, want) {
+			continue
+		}
+		r.used[n] = true
+
+		resp := &http.Response{
+			StatusCode:    i.Response.Status,
+			Status:        fmt.Sprintf("%d %s", i.Response.Status, http.StatusText(i.Response.Status)),
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{},
+			Body:          io.NopCloser(bytes.NewReader(i.Response.Body)),
+			ContentLength: int64(len(i.Response.Body)),
+			Request:       req,
+		}
+		for k, v := range i.Response.Headers {
+			resp.Header.Set(k, v)
+		}
+		return resp, nil
+	}
+	return nil, fmt.Errorf("cassette %s: no recorded interaction for %s %s", filepath.Base(r.path), want.Method, want.Path)
+}
+
+// matches compares requests by method, path and JSON body, ignoring
+// formatting and key order.
+func matches(recorded, req Request) bool {
+	if recorded.Method != req.Method || recorded.Path != req.Path {
+		return false
+	}
+	if len(recorded.Body) == 0 || len(req.Body) == 0 {
+		return len(recorded.Body) == len(req.Body)
+	}
+	var a, b interface{}
+	if json.Unmarshal(recorded.Body, &a) != nil || json.Unmarshal(req.Body, &b) != nil {
+		return bytes.Equal(recorded.Body, req.Body)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// Stop writes the recorded interactions in ModeRecord. In ModeReplay it
+// reports interactions that were never requested, which usually means the
+// cassette is stale.
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode == ModeReplay {
+		var unused []string
+		for n, i := range r.interactions {
+			if !r.used[n] {
+				unused = append(unused, i.Request.Method+" "+i.Request.Path)
+			}
+		}
+		if len(unused) > 0 {
+			return fmt.Errorf("cassette %s: %d recorded interactions were not replayed: %s", filepath.Base(r.path), len(unused), strings.Join(unused, ", "))
+		}
+		return nil
+	}
+
+	if len(r.interactions) == 0 {
+		return errors.New("cassette: nothing was recorded")
+	}
+	b, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(b, '\n'), 0o644)
+}
+
+// scrubJSON masks secret fields in a JSON body and replaces origin with
+// Host. Bodies that are not JSON are stored as JSON strings.
+func scrubJSON(body []byte, origin string) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		b, _ := json.Marshal(string(body))
+		return b
+	}
+	b, err := json.Marshal(scrubValue(v, origin))
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+func scrubValue(v interface{}, origin string) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			if isSecretKey(k) {
+				// The controller already masks stored secrets; keep that
+				// marker so replays look like real responses.
+				if s, ok := val.(string); ok && s != "" && s != "$encrypted$" {
+					v[k] = Masked
+				}
+				continue
+			}
+			v[k] = scrubValue(val, origin)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = scrubValue(v[i], origin)
+		}
+	case string:
+		if origin != "" {
+			return strings.ReplaceAll(v, origin, Host)
+		}
+	}
+	return v
+}
+
+func isSecretKey(k string) bool {
+	k = strings.ToLower(k)
+	return secretKeys[k] || strings.Contains(k, "password") || strings.Contains(k, "secret")
+}
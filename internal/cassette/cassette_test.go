@@ -0,0 +1,128 @@
+package cassette
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "sessionid=abc")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":     calls,
+			"url":    "http://" + r.Host + "/api/v2/credentials/1/",
+			"inputs": map[string]string{"username": "deploy", "password": "$encrypted$"},
+		})
+	}))
+	defer ts.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	rec, err := New(path, ModeRecord, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: rec}
+	body := `{"name":"cred","inputs":{"username":"deploy","password":"hunter2"}}`
+	resp, err := client.Post(ts.URL+"/api/v2/credentials/?x=1", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop failed: %s", err)
+	}
+
+	b, _ := os.ReadFile(path)
+	if strings.Contains(string(b), "hunter2") {
+		t.Errorf("Expected password to be scrubbed, got %s", b)
+	}
+	if strings.Contains(string(b), ts.URL) || strings.Contains(string(b), "sessionid") {
+		t.Errorf("Expected host and cookies to be scrubbed, got %s", b)
+	}
+	if !strings.Contains(string(b), "$encrypted$") {
+		t.Errorf("Expected controller mask to be kept, got %s", b)
+	}
+
+	rec, err = New(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = &http.Client{Transport: rec}
+	// Matching ignores key order and the secret's value.
+	body = `{"inputs":{"password":"other","username":"deploy"},"name":"cred"}`
+	resp, err = client.Post(Host+"/api/v2/credentials/?x=1", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Replay failed: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated || resp.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("Expected recorded status and headers, got %d %v", resp.StatusCode, resp.Header)
+	}
+	var got map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&got)
+	if got["url"] != Host+"/api/v2/credentials/1/" {
+		t.Errorf("Expected url on placeholder host, got %v", got["url"])
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call to the server, got %d", calls)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Errorf("Stop failed: %s", err)
+	}
+}
+
+func TestReplayMatching(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	os.WriteFile(path, []byte(`[
+		{"request": {"method": "GET", "path": "/api/v2/organizations/1/"}, "response": {"status": 200, "body": {"name": "first"}}},
+		{"request": {"method": "GET", "path": "/api/v2/organizations/1/"}, "response": {"status": 404, "body": {"detail": "Not found."}}},
+		{"request": {"method": "PATCH", "path": "/api/v2/organizations/1/", "body": {"name": "b"}}, "response": {"status": 200}}
+	]`), 0o644)
+
+	rec, err := New(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: rec}
+
+	for _, want := range []int{200, 404} {
+		resp, err := client.Get(Host + "/api/v2/organizations/1/")
+		if err != nil {
+			t.Fatalf("Replay failed: %s", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("Expected status %d, got %d", want, resp.StatusCode)
+		}
+	}
+
+	if _, err := client.Get(Host + "/api/v2/organizations/1/"); err == nil {
+		t.Error("Expected error once recorded interactions are used up")
+	}
+
+	req, _ := http.NewRequest(http.MethodPatch, Host+"/api/v2/organizations/1/", strings.NewReader(`{"name":"a"}`))
+	if _, err := client.Do(req); err == nil || !strings.Contains(err.Error(), "no recorded interaction for PATCH") {
+		t.Errorf("Expected body mismatch error, got %v", err)
+	}
+
+	if err := rec.Stop(); err == nil || !strings.Contains(err.Error(), "1 recorded interactions were not replayed") {
+		t.Errorf("Expected unused interaction error, got %v", err)
+	}
+}
+
+func TestReplayMissingCassette(t *testing.T) {
+	if _, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay, nil); err == nil {
+		t.Error("Expected error for missing cassette")
+	}
+}
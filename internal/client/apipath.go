@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DefaultAPIPath is the controller API served through the AAP 2.5 Platform
+// Gateway.
+const DefaultAPIPath = "/api/controller/v2/"
+
+// apiRoot is the subset of the /api/ and /api/controller/ responses used to
+// locate the controller API. The gateway lists the services it proxies under
+// "apis"; a controller serving /api/ directly (AWX, AAP 2.4) reports its
+// "current_version" instead.
+type apiRoot struct {
+	APIs           map[string]string `json:"apis"`
+	CurrentVersion string            `json:"current_version"`
+}
+
+// DiscoverAPIPath sets APIPath from the root /api/ endpoint, so the same
+// client works against the 2.5 gateway and against controllers that serve
+// /api/v2/ directly.
+func (c *Client) DiscoverAPIPath(ctx context.Context) error {
+	root, err := c.getAPIRoot(ctx, "/api/")
+	if err != nil {
+		return err
+	}
+	if root.CurrentVersion == "" {
+		controller, ok := root.APIs["controller"]
+		if !ok {
+			return fmt.Errorf("%s/api/ does not list a controller API", c.Host)
+		}
+		if root, err = c.getAPIRoot(ctx, controller); err != nil {
+			return err
+		}
+		if root.CurrentVersion == "" {
+			return fmt.Errorf("%s%s does not report a current API version", c.Host, controller)
+		}
+	}
+
+	c.APIPath = NormalizeAPIPath(root.CurrentVersion)
+	return nil
+}
+
+func (c *Client) getAPIRoot(ctx context.Context, path string) (*apiRoot, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var root apiRoot
+	if err := json.Unmarshal(resp, &root); err != nil {
+		return nil, fmt.Errorf("decoding %s response: %w", path, err)
+	}
+	return &root, nil
+}
+
+// NormalizeAPIPath returns p with exactly one leading and trailing slash.
+func NormalizeAPIPath(p string) string {
+	return "/" + strings.Trim(p, "/") + "/"
+}
+
+// behindGateway reports whether the controller API is proxied by the
+// Platform Gateway, which then also serves authentication.
+func (c *Client) behindGateway() bool {
+	return strings.HasPrefix(c.APIPath, "/api/controller/")
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDiscoverAPIPathGateway(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/":
+			w.Write([]byte(`{"apis": {"gateway": "/api/gateway/", "controller": "/api/controller/", "eda": "/api/eda/"}}`))
+		case "/api/controller/":
+			w.Write([]byte(`{"current_version": "/api/controller/v2/", "available_versions": {"v2": "/api/controller/v2/"}}`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "admin", "password", "", false)
+	if err := c.DiscoverAPIPath(context.Background()); err != nil {
+		t.Fatalf("DiscoverAPIPath failed: %s", err)
+	}
+	if c.APIPath != "/api/controller/v2/" {
+		t.Errorf("Expected /api/controller/v2/, got %s", c.APIPath)
+	}
+}
+
+func TestDiscoverAPIPathLegacyController(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/":
+			w.Write([]byte(`{"description": "AWX REST API", "current_version": "/api/v2/", "available_versions": {"v2": "/api/v2/"}}`))
+		case "/api/v2/organizations/1/":
+			json.NewEncoder(w).Encode(Organization{ID: 1, Name: "Test Org"})
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "admin", "password", "", false)
+	if err := c.DiscoverAPIPath(context.Background()); err != nil {
+		t.Fatalf("DiscoverAPIPath failed: %s", err)
+	}
+	if c.APIPath != "/api/v2/" {
+		t.Errorf("Expected /api/v2/, got %s", c.APIPath)
+	}
+	if _, err := c.Organizations.Get(context.Background(), 1); err != nil {
+		t.Errorf("Get failed: %s", err)
+	}
+}
+
+func TestLegacyControllerAuthPaths(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/tokens/":
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(OAuth2Token{ID: 3, Token: "legacy"})
+		case "/api/v2/tokens/3/":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "admin", "password", "", false)
+	c.APIPath = "/api/v2/"
+	tok, err := c.AcquireOAuth2Token(context.Background(), "terraform")
+	if err != nil {
+		t.Fatalf("AcquireOAuth2Token failed: %s", err)
+	}
+	if err := c.RevokeOAuth2Token(context.Background(), tok); err != nil {
+		t.Errorf("RevokeOAuth2Token failed: %s", err)
+	}
+}
+
+func TestNormalizeAPIPath(t *testing.T) {
+	for in, want := range map[string]string{
+		"api/v2":             "/api/v2/",
+		"/api/v2/":           "/api/v2/",
+		"/api/controller/v2": "/api/controller/v2/",
+	} {
+		if got := NormalizeAPIPath(in); got != want {
+			t.Errorf("NormalizeAPIPath(%q): expected %q, got %q", in, want, got)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAcquireAndRevokeOAuth2Token(t *testing.T) {
+	var revoked bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/gateway/v1/tokens/":
+			if user, pass, ok := r.BasicAuth(); !ok || user != "admin" || pass != "secret" {
+				t.Errorf("Expected Basic auth for token exchange, got %q", r.Header.Get("Authorization"))
+			}
+			var req OAuth2Token
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Scope != "write" {
+				t.Errorf("Expected scope write, got %q", req.Scope)
+			}
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(OAuth2Token{ID: 11, Token: "pat-123", Scope: "write"})
+		case r.Method == "GET" && r.URL.Path == "/api/controller/v2/organizations/1/":
+			if got := r.Header.Get("Authorization"); got != "Bearer pat-123" {
+				t.Errorf("Expected Bearer pat-123, got %q", got)
+			}
+			json.NewEncoder(w).Encode(Organization{ID: 1, Name: "Test Org"})
+		case r.Method == "DELETE" && r.URL.Path == "/api/gateway/v1/tokens/11/":
+			if got := r.Header.Get("Authorization"); got != "Bearer pat-123" {
+				t.Errorf("Expected Bearer pat-123 for revocation, got %q", got)
+			}
+			revoked = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "admin", "secret", "", false)
+	tok, err := c.AcquireOAuth2Token(context.Background(), "terraform")
+	if err != nil {
+		t.Fatalf("AcquireOAuth2Token failed: %s", err)
+	}
+	if _, err := c.Organizations.Get(context.Background(), 1); err != nil {
+		t.Fatalf("Get failed: %s", err)
+	}
+	if err := c.RevokeOAuth2Token(context.Background(), tok); err != nil {
+		t.Fatalf("RevokeOAuth2Token failed: %s", err)
+	}
+	if !revoked {
+		t.Error("Expected token to be revoked")
+	}
+}
+
+func TestAcquireOAuth2TokenRequiresCredentials(t *testing.T) {
+	c := NewClient("https://aap.example.com", "", "", "", false)
+	if _, err := c.AcquireOAuth2Token(context.Background(), "terraform"); err == nil {
+		t.Error("Expected error without username and password")
+	}
+}
@@ -2,15 +2,23 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
 )
 
+// DefaultUserAgent is sent when the caller does not set Client.UserAgent.
+const DefaultUserAgent = "terraform-provider-aap"
+
 type Client struct {
 	Host     string
 	Username string
@@ -18,6 +26,37 @@ type Client struct {
 	Token    string
 	Insecure bool
 	HTTP     *http.Client
+	Retry    RetryPolicy
+
+	// APIPath is the controller API prefix that endpoint paths are relative
+	// to, e.g. "/api/controller/v2/" or "/api/v2/". See DiscoverAPIPath.
+	APIPath string
+	// Version is the server version found by DetectVersion, or nil if
+	// unknown.
+	Version *ServerVersion
+
+	// UserAgent is sent with every request.
+	UserAgent string
+	// Headers are added to every request, e.g. a tenant header required by
+	// the Platform Gateway. They cannot override authentication.
+	Headers http.Header
+
+	transport *http.Transport
+	session   *session
+	slots     chan struct{}
+	limiter   *rate.Limiter
+
+	managedTypesMu sync.Mutex
+	managedTypes   map[string]int
+
+	Organizations    *Endpoint[Organization]
+	Inventories      *Endpoint[Inventory]
+	JobTemplates     *Endpoint[JobTemplate]
+	Projects         *Endpoint[Project]
+	Credentials      *Endpoint[Credential]
+	InventorySources *Endpoint[InventorySource]
+	CredentialTypes  *Endpoint[CredentialType]
+	InventoryScripts *Endpoint[InventoryScript]
 }
 
 type Organization struct {
@@ -30,6 +69,7 @@ type Organization struct {
 
 func NewClient(host, username, password, token string, insecure bool) *Client {
 	tr := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure},
 	}
 
@@ -43,87 +83,157 @@ func NewClient(host, username, password, token string, insecure bool) *Client {
 			Timeout:   30 * time.Second,
 			Transport: tr,
 		},
+		Retry:     DefaultRetryPolicy(),
+		APIPath:   DefaultAPIPath,
+		UserAgent: DefaultUserAgent,
+		transport: tr,
 	}
 
+	c.Organizations = NewEndpoint(c, "organizations/", func(o *Organization) int { return o.ID })
+	c.Inventories = NewEndpoint(c, "inventories/", func(i *Inventory) int { return i.ID })
+	c.JobTemplates = NewEndpoint(c, "job_templates/", func(jt *JobTemplate) int { return jt.ID })
+	c.Projects = NewEndpoint(c, "projects/", func(p *Project) int { return p.ID })
+	c.Credentials = NewEndpoint(c, "credentials/", func(cred *Credential) int { return cred.ID })
+	c.InventorySources = NewEndpoint(c, "inventory_sources/", func(is *InventorySource) int { return is.ID })
+	c.CredentialTypes = NewEndpoint(c, "credential_types/", func(ct *CredentialType) int { return ct.ID })
+	c.InventoryScripts = NewEndpoint(c, "inventory_scripts/", func(is *InventoryScript) int { return is.ID })
+
 	return c
 }
 
-func (c *Client) doRequest(method, path string, body interface{}) ([]byte, error) {
-	var reqBody io.Reader
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	var payload []byte
 	if body != nil {
 		b, err := json.Marshal(body)
 		if err != nil {
 			return nil, err
 		}
-		reqBody = bytes.NewBuffer(b)
+		payload = b
 	}
 
-	req, err := http.NewRequest(method, fmt.Sprintf("%s%s", c.Host, path), reqBody)
-	if err != nil {
-		return nil, err
-	}
+	ctx = c.logContext(ctx)
 
-	req.Header.Set("Content-Type", "application/json")
-	if c.Token != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
-	} else if c.Username != "" && c.Password != "" {
-		req.SetBasicAuth(c.Username, c.Password)
+	var generation int
+	var relogged bool
+	if c.session != nil {
+		generation = c.session.current()
 	}
 
-	resp, err := c.HTTP.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	for attempt := 0; ; attempt++ {
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payload)
+		}
 
-	if resp.StatusCode >= 400 {
-		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error: %s - %s", resp.Status, string(respBody))
-	}
+		req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s", c.Host, path), reqBody)
+		if err != nil {
+			return nil, err
+		}
 
-	return io.ReadAll(resp.Body)
-}
+		c.setCommonHeaders(req)
+		req.Header.Set("Content-Type", "application/json")
+		if c.session != nil {
+			if !isSafeMethod(method) {
+				req.Header.Set("X-CSRFToken", c.cookie("csrftoken"))
+				req.Header.Set("Referer", c.Host+"/")
+			}
+		} else if c.Token != "" {
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
+		} else if c.Username != "" && c.Password != "" {
+			req.SetBasicAuth(c.Username, c.Password)
+		}
 
-// GetOrganization retrieves an organization by ID
-func (c *Client) GetOrganization(id int) (*Organization, error) {
-	resp, err := c.doRequest("GET", fmt.Sprintf("/api/controller/v2/organizations/%d/", id), nil)
-	if err != nil {
-		return nil, err
-	}
+		resp, respBody, err := c.send(ctx, req, path, attempt, payload)
 
-	var org Organization
-	err = json.Unmarshal(resp, &org)
-	return &org, err
-}
+		if c.session != nil && !relogged && err == nil && sessionExpired(resp, respBody) {
+			relogged = true
+			if err := c.login(ctx, generation); err != nil {
+				return nil, err
+			}
+			continue
+		}
 
-// CreateOrganization creates a new organization
-func (c *Client) CreateOrganization(org *Organization) (*Organization, error) {
-	resp, err := c.doRequest("POST", "/api/controller/v2/organizations/", org)
-	if err != nil {
-		return nil, err
-	}
+		if c.Retry.shouldRetry(ctx, method, attempt, resp, err) {
+			if err := sleepContext(ctx, c.Retry.backoff(attempt, resp)); err != nil {
+				return nil, err
+			}
+			continue
+		}
 
-	var newOrg Organization
-	err = json.Unmarshal(resp, &newOrg)
-	return &newOrg, err
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode >= 400 {
+			return nil, newAPIError(method, req.URL.String(), resp.StatusCode, respBody)
+		}
+		return respBody, nil
+	}
 }
 
-// UpdateOrganization updates an existing organization
-func (c *Client) UpdateOrganization(org *Organization) (*Organization, error) {
-	resp, err := c.doRequest("PATCH", fmt.Sprintf("/api/controller/v2/organizations/%d/", org.ID), org)
+// send makes one attempt at req, logging it and holding a slot of the request
+// limits while it is in flight. path identifies the request in logs, and
+// payload is the request body to log once sensitive fields are redacted. The
+// response body has been read and closed when send returns.
+func (c *Client) send(ctx context.Context, req *http.Request, path string, attempt int, payload []byte) (*http.Response, []byte, error) {
+	method := req.Method
+	tflog.Debug(ctx, "Sending AAP API request", map[string]interface{}{
+		"method":  method,
+		"path":    path,
+		"attempt": attempt + 1,
+	})
+	tflog.Trace(ctx, "AAP API request details", map[string]interface{}{
+		"method":  method,
+		"path":    path,
+		"headers": redactHeaders(req.Header),
+		"body":    redactBody(payload),
+	})
+
+	release, err := c.acquire(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	start := time.Now()
+	var respBody []byte
+	resp, err := c.HTTP.Do(req)
+	if err == nil {
+		respBody, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
 	}
+	release()
 
-	var updatedOrg Organization
-	err = json.Unmarshal(resp, &updatedOrg)
-	return &updatedOrg, err
+	if err != nil {
+		tflog.Debug(ctx, "AAP API request failed", map[string]interface{}{
+			"method":     method,
+			"path":       path,
+			"latency_ms": time.Since(start).Milliseconds(),
+			"error":      err.Error(),
+		})
+	} else {
+		tflog.Debug(ctx, "Received AAP API response", map[string]interface{}{
+			"method":     method,
+			"path":       path,
+			"status":     resp.StatusCode,
+			"latency_ms": time.Since(start).Milliseconds(),
+			"request_id": requestID(resp),
+		})
+		tflog.Trace(ctx, "AAP API response details", map[string]interface{}{
+			"method":  method,
+			"path":    path,
+			"status":  resp.StatusCode,
+			"headers": redactHeaders(resp.Header),
+			"body":    redactBody(respBody),
+		})
+	}
+	return resp, respBody, err
 }
 
-// DeleteOrganization deletes an organization
-func (c *Client) DeleteOrganization(id int) error {
-	_, err := c.doRequest("DELETE", fmt.Sprintf("/api/controller/v2/organizations/%d/", id), nil)
-	return err
+// setCommonHeaders sets the User-Agent and custom headers sent with every
+// request, including session logins.
+func (c *Client) setCommonHeaders(req *http.Request) {
+	req.Header.Set("User-Agent", c.UserAgent)
+	for name, values := range c.Headers {
+		req.Header[http.CanonicalHeaderKey(name)] = values
+	}
 }
 
 type Inventory struct {
@@ -136,54 +246,12 @@ type Inventory struct {
 	Variables    string `json:"variables,omitempty"`
 }
 
-// GetInventory retrieves an inventory by ID
-func (c *Client) GetInventory(id int) (*Inventory, error) {
-	resp, err := c.doRequest("GET", fmt.Sprintf("/api/controller/v2/inventories/%d/", id), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var inv Inventory
-	err = json.Unmarshal(resp, &inv)
-	return &inv, err
-}
-
-// CreateInventory creates a new inventory
-func (c *Client) CreateInventory(inv *Inventory) (*Inventory, error) {
-	resp, err := c.doRequest("POST", "/api/controller/v2/inventories/", inv)
-	if err != nil {
-		return nil, err
-	}
-
-	var newInv Inventory
-	err = json.Unmarshal(resp, &newInv)
-	return &newInv, err
-}
-
-// UpdateInventory updates an existing inventory
-func (c *Client) UpdateInventory(inv *Inventory) (*Inventory, error) {
-	resp, err := c.doRequest("PATCH", fmt.Sprintf("/api/controller/v2/inventories/%d/", inv.ID), inv)
-	if err != nil {
-		return nil, err
-	}
-
-	var updatedInv Inventory
-	err = json.Unmarshal(resp, &updatedInv)
-	return &updatedInv, err
-}
-
-// DeleteInventory deletes an inventory
-func (c *Client) DeleteInventory(id int) error {
-	_, err := c.doRequest("DELETE", fmt.Sprintf("/api/controller/v2/inventories/%d/", id), nil)
-	return err
-}
-
 type JobTemplate struct {
 	ID           int    `json:"id,omitempty"`
 	Name         string `json:"name"`
 	Description  string `json:"description,omitempty"`
 	JobType      string `json:"job_type"`
-	Inventory    int    `json:"inventory"`
+	Inventory    int    `json:"inventory,omitempty"`
 	Project      int    `json:"project"`
 	Playbook     string `json:"playbook"`
 	Forks        int    `json:"forks,omitempty"`
@@ -192,48 +260,6 @@ type JobTemplate struct {
 	ExtraVars    string `json:"extra_vars,omitempty"`
 }
 
-// GetJobTemplate retrieves a job template by ID
-func (c *Client) GetJobTemplate(id int) (*JobTemplate, error) {
-	resp, err := c.doRequest("GET", fmt.Sprintf("/api/controller/v2/job_templates/%d/", id), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var jt JobTemplate
-	err = json.Unmarshal(resp, &jt)
-	return &jt, err
-}
-
-// CreateJobTemplate creates a new job template
-func (c *Client) CreateJobTemplate(jt *JobTemplate) (*JobTemplate, error) {
-	resp, err := c.doRequest("POST", "/api/controller/v2/job_templates/", jt)
-	if err != nil {
-		return nil, err
-	}
-
-	var newJt JobTemplate
-	err = json.Unmarshal(resp, &newJt)
-	return &newJt, err
-}
-
-// UpdateJobTemplate updates an existing job template
-func (c *Client) UpdateJobTemplate(jt *JobTemplate) (*JobTemplate, error) {
-	resp, err := c.doRequest("PATCH", fmt.Sprintf("/api/controller/v2/job_templates/%d/", jt.ID), jt)
-	if err != nil {
-		return nil, err
-	}
-
-	var updatedJt JobTemplate
-	err = json.Unmarshal(resp, &updatedJt)
-	return &updatedJt, err
-}
-
-// DeleteJobTemplate deletes a job template
-func (c *Client) DeleteJobTemplate(id int) error {
-	_, err := c.doRequest("DELETE", fmt.Sprintf("/api/controller/v2/job_templates/%d/", id), nil)
-	return err
-}
-
 // ==================== PROJECT ====================
 
 type Project struct {
@@ -252,96 +278,29 @@ type Project struct {
 	LocalPath             string `json:"local_path,omitempty"`
 }
 
-func (c *Client) GetProject(id int) (*Project, error) {
-	resp, err := c.doRequest("GET", fmt.Sprintf("/api/controller/v2/projects/%d/", id), nil)
-	if err != nil {
-		return nil, err
-	}
-	var p Project
-	err = json.Unmarshal(resp, &p)
-	return &p, err
-}
-
-func (c *Client) CreateProject(p *Project) (*Project, error) {
-	resp, err := c.doRequest("POST", "/api/controller/v2/projects/", p)
-	if err != nil {
-		return nil, err
-	}
-	var newP Project
-	err = json.Unmarshal(resp, &newP)
-	return &newP, err
-}
-
-func (c *Client) UpdateProject(p *Project) (*Project, error) {
-	resp, err := c.doRequest("PATCH", fmt.Sprintf("/api/controller/v2/projects/%d/", p.ID), p)
-	if err != nil {
-		return nil, err
-	}
-	var updated Project
-	err = json.Unmarshal(resp, &updated)
-	return &updated, err
-}
-
-func (c *Client) DeleteProject(id int) error {
-	_, err := c.doRequest("DELETE", fmt.Sprintf("/api/controller/v2/projects/%d/", id), nil)
-	return err
-}
-
 // ==================== CREDENTIAL ====================
 
-type CredentialInputs struct {
-	Username         string `json:"username,omitempty"`
-	Password         string `json:"password,omitempty"`
-	SSHKeyData       string `json:"ssh_key_data,omitempty"`
-	SSHPublicKeyData string `json:"ssh_public_key_data,omitempty"`
-	SSHKeyUnlock     string `json:"ssh_key_unlock,omitempty"`
-	BecomeMethod     string `json:"become_method,omitempty"`
-	BecomeUsername   string `json:"become_username,omitempty"`
-	BecomePassword   string `json:"become_password,omitempty"`
+// CredentialInputs holds a credential's inputs keyed by the field IDs of its
+// credential type. Values are usually strings, or booleans for boolean
+// fields. The controller returns secret inputs as "$encrypted$".
+type CredentialInputs map[string]interface{}
+
+// String returns the string input key, or "" if it is unset or not a string.
+func (in CredentialInputs) String(key string) string {
+	s, _ := in[key].(string)
+	return s
 }
 
 type Credential struct {
 	ID             int              `json:"id,omitempty"`
 	Name           string           `json:"name"`
 	Description    string           `json:"description,omitempty"`
-	Organization   int              `json:"organization"`
+	Organization   int              `json:"organization,omitempty"`
 	CredentialType int              `json:"credential_type"`
 	Inputs         CredentialInputs `json:"inputs,omitempty"`
-}
-
-func (c *Client) GetCredential(id int) (*Credential, error) {
-	resp, err := c.doRequest("GET", fmt.Sprintf("/api/controller/v2/credentials/%d/", id), nil)
-	if err != nil {
-		return nil, err
-	}
-	var cred Credential
-	err = json.Unmarshal(resp, &cred)
-	return &cred, err
-}
-
-func (c *Client) CreateCredential(cred *Credential) (*Credential, error) {
-	resp, err := c.doRequest("POST", "/api/controller/v2/credentials/", cred)
-	if err != nil {
-		return nil, err
-	}
-	var newCred Credential
-	err = json.Unmarshal(resp, &newCred)
-	return &newCred, err
-}
-
-func (c *Client) UpdateCredential(cred *Credential) (*Credential, error) {
-	resp, err := c.doRequest("PATCH", fmt.Sprintf("/api/controller/v2/credentials/%d/", cred.ID), cred)
-	if err != nil {
-		return nil, err
-	}
-	var updated Credential
-	err = json.Unmarshal(resp, &updated)
-	return &updated, err
-}
-
-func (c *Client) DeleteCredential(id int) error {
-	_, err := c.doRequest("DELETE", fmt.Sprintf("/api/controller/v2/credentials/%d/", id), nil)
-	return err
+	// Modified is when the credential last changed, including its secret
+	// inputs, which the controller never returns.
+	Modified string `json:"modified,omitempty"`
 }
 
 // ==================== INVENTORY SOURCE ====================
@@ -362,85 +321,17 @@ type InventorySource struct {
 	OverwriteVars      bool   `json:"overwrite_vars,omitempty"`
 }
 
-func (c *Client) GetInventorySource(id int) (*InventorySource, error) {
-	resp, err := c.doRequest("GET", fmt.Sprintf("/api/controller/v2/inventory_sources/%d/", id), nil)
-	if err != nil {
-		return nil, err
-	}
-	var is InventorySource
-	err = json.Unmarshal(resp, &is)
-	return &is, err
-}
-
-func (c *Client) CreateInventorySource(is *InventorySource) (*InventorySource, error) {
-	resp, err := c.doRequest("POST", "/api/controller/v2/inventory_sources/", is)
-	if err != nil {
-		return nil, err
-	}
-	var newIS InventorySource
-	err = json.Unmarshal(resp, &newIS)
-	return &newIS, err
-}
-
-func (c *Client) UpdateInventorySource(is *InventorySource) (*InventorySource, error) {
-	resp, err := c.doRequest("PATCH", fmt.Sprintf("/api/controller/v2/inventory_sources/%d/", is.ID), is)
-	if err != nil {
-		return nil, err
-	}
-	var updated InventorySource
-	err = json.Unmarshal(resp, &updated)
-	return &updated, err
-}
-
-func (c *Client) DeleteInventorySource(id int) error {
-	_, err := c.doRequest("DELETE", fmt.Sprintf("/api/controller/v2/inventory_sources/%d/", id), nil)
-	return err
-}
-
 // ==================== CREDENTIAL TYPE ====================
 
 type CredentialType struct {
-	ID          int    `json:"id,omitempty"`
-	Name        string `json:"name"`
-	Description string `json:"description,omitempty"`
-	Kind        string `json:"kind"`
-	Inputs      string `json:"inputs,omitempty"`
-	Injectors   string `json:"injectors,omitempty"`
-}
-
-func (c *Client) GetCredentialType(id int) (*CredentialType, error) {
-	resp, err := c.doRequest("GET", fmt.Sprintf("/api/controller/v2/credential_types/%d/", id), nil)
-	if err != nil {
-		return nil, err
-	}
-	var ct CredentialType
-	err = json.Unmarshal(resp, &ct)
-	return &ct, err
-}
-
-func (c *Client) CreateCredentialType(ct *CredentialType) (*CredentialType, error) {
-	resp, err := c.doRequest("POST", "/api/controller/v2/credential_types/", ct)
-	if err != nil {
-		return nil, err
-	}
-	var newCT CredentialType
-	err = json.Unmarshal(resp, &newCT)
-	return &newCT, err
-}
-
-func (c *Client) UpdateCredentialType(ct *CredentialType) (*CredentialType, error) {
-	resp, err := c.doRequest("PATCH", fmt.Sprintf("/api/controller/v2/credential_types/%d/", ct.ID), ct)
-	if err != nil {
-		return nil, err
-	}
-	var updated CredentialType
-	err = json.Unmarshal(resp, &updated)
-	return &updated, err
-}
-
-func (c *Client) DeleteCredentialType(id int) error {
-	_, err := c.doRequest("DELETE", fmt.Sprintf("/api/controller/v2/credential_types/%d/", id), nil)
-	return err
+	ID          int             `json:"id,omitempty"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Kind        string          `json:"kind"`
+	Namespace   string          `json:"namespace,omitempty"`
+	Managed     bool            `json:"managed,omitempty"`
+	Inputs      json.RawMessage `json:"inputs,omitempty"`
+	Injectors   json.RawMessage `json:"injectors,omitempty"`
 }
 
 // ==================== INVENTORY SCRIPT ====================
@@ -453,37 +344,3 @@ type InventoryScript struct {
 	Script       string `json:"script"`
 }
 
-func (c *Client) GetInventoryScript(id int) (*InventoryScript, error) {
-	resp, err := c.doRequest("GET", fmt.Sprintf("/api/controller/v2/inventory_scripts/%d/", id), nil)
-	if err != nil {
-		return nil, err
-	}
-	var is InventoryScript
-	err = json.Unmarshal(resp, &is)
-	return &is, err
-}
-
-func (c *Client) CreateInventoryScript(is *InventoryScript) (*InventoryScript, error) {
-	resp, err := c.doRequest("POST", "/api/controller/v2/inventory_scripts/", is)
-	if err != nil {
-		return nil, err
-	}
-	var newIS InventoryScript
-	err = json.Unmarshal(resp, &newIS)
-	return &newIS, err
-}
-
-func (c *Client) UpdateInventoryScript(is *InventoryScript) (*InventoryScript, error) {
-	resp, err := c.doRequest("PATCH", fmt.Sprintf("/api/controller/v2/inventory_scripts/%d/", is.ID), is)
-	if err != nil {
-		return nil, err
-	}
-	var updated InventoryScript
-	err = json.Unmarshal(resp, &updated)
-	return &updated, err
-}
-
-func (c *Client) DeleteInventoryScript(id int) error {
-	_, err := c.doRequest("DELETE", fmt.Sprintf("/api/controller/v2/inventory_scripts/%d/", id), nil)
-	return err
-}
@@ -1,10 +1,13 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestGetOrganization(t *testing.T) {
@@ -26,7 +29,7 @@ func TestGetOrganization(t *testing.T) {
 	defer ts.Close()
 
 	c := NewClient(ts.URL, "user", "pass", "", true)
-	org, err := c.GetOrganization(1)
+	org, err := c.Organizations.Get(context.Background(), 1)
 	if err != nil {
 		t.Fatalf("GetOrganization failed: %s", err)
 	}
@@ -64,7 +67,7 @@ func TestCreateJobTemplate(t *testing.T) {
 	defer ts.Close()
 
 	c := NewClient(ts.URL, "user", "pass", "", true)
-	jt, err := c.CreateJobTemplate(&JobTemplate{
+	jt, err := c.JobTemplates.Create(context.Background(), &JobTemplate{
 		Name:    "New Job",
 		JobType: "run",
 	})
@@ -76,3 +79,24 @@ func TestCreateJobTemplate(t *testing.T) {
 		t.Errorf("Expected ID 2, got %d", jt.ID)
 	}
 }
+
+func TestRequestCanceledWithContext(t *testing.T) {
+	block := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer ts.Close()
+	defer close(block)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	c := NewClient(ts.URL, "user", "pass", "", true)
+	_, err := c.Organizations.Get(ctx, 1)
+	if err == nil {
+		t.Fatal("Expected error from canceled context, got nil")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %s", err)
+	}
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Namespaces of the managed credential types modeled by dedicated resources.
+const (
+	NamespaceMachine       = "ssh"
+	NamespaceSourceControl = "scm"
+)
+
+// ManagedCredentialTypeID returns the ID of the managed credential type with
+// the given namespace, e.g. NamespaceMachine. IDs of managed types differ
+// between controllers, so they are looked up through the credential_types
+// list and cached for the life of the client.
+func (c *Client) ManagedCredentialTypeID(ctx context.Context, namespace string) (int, error) {
+	c.managedTypesMu.Lock()
+	defer c.managedTypesMu.Unlock()
+
+	if id, ok := c.managedTypes[namespace]; ok {
+		return id, nil
+	}
+
+	var ids []int
+	opts := ListOptions{Filters: map[string]string{"managed": "true", "namespace": namespace}}
+	for ct, err := range c.CredentialTypes.List(ctx, opts) {
+		if err != nil {
+			return 0, fmt.Errorf("looking up the %q credential type: %w", namespace, err)
+		}
+		ids = append(ids, ct.ID)
+	}
+	switch len(ids) {
+	case 0:
+		return 0, fmt.Errorf("the controller has no managed credential type with namespace %q", namespace)
+	case 1:
+	default:
+		return 0, fmt.Errorf("the controller has %d managed credential types with namespace %q", len(ids), namespace)
+	}
+
+	if c.managedTypes == nil {
+		c.managedTypes = map[string]int{}
+	}
+	c.managedTypes[namespace] = ids[0]
+	return ids[0], nil
+}
+
+// CredentialTypeInputs is the input schema of a credential type, given in
+// its inputs field.
+type CredentialTypeInputs struct {
+	Fields []CredentialTypeField `json:"fields"`
+	// Required lists the IDs of fields that must have a value.
+	Required []string `json:"required"`
+}
+
+// CredentialTypeField describes one input of a credential type.
+type CredentialTypeField struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+	// Type is "string" or "boolean". It defaults to "string".
+	Type      string   `json:"type"`
+	Secret    bool     `json:"secret"`
+	Choices   []string `json:"choices"`
+	Format    string   `json:"format"`
+	Multiline bool     `json:"multiline"`
+}
+
+// InputSchema decodes the input schema of ct.
+func (ct *CredentialType) InputSchema() (*CredentialTypeInputs, error) {
+	schema := &CredentialTypeInputs{}
+	if len(ct.Inputs) == 0 {
+		return schema, nil
+	}
+	if err := json.Unmarshal(ct.Inputs, schema); err != nil {
+		return nil, fmt.Errorf("decoding inputs of credential type %q: %w", ct.Name, err)
+	}
+	for i := range schema.Fields {
+		if schema.Fields[i].Type == "" {
+			schema.Fields[i].Type = "string"
+		}
+	}
+	return schema, nil
+}
+
+// Field returns the field with the given ID, or nil if there is none.
+func (in *CredentialTypeInputs) Field(id string) *CredentialTypeField {
+	for i := range in.Fields {
+		if in.Fields[i].ID == id {
+			return &in.Fields[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestManagedCredentialTypeID(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/api/controller/v2/credential_types/" {
+			t.Errorf("Expected path /api/controller/v2/credential_types/, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("managed") != "true" {
+			t.Errorf("Expected managed=true filter, got %s", r.URL.RawQuery)
+		}
+
+		var results []CredentialType
+		switch r.URL.Query().Get("namespace") {
+		case "ssh":
+			results = []CredentialType{{ID: 17, Name: "Machine", Kind: "ssh", Namespace: "ssh", Managed: true}}
+		case "scm":
+			results = []CredentialType{{ID: 23, Name: "Source Control", Kind: "scm", Namespace: "scm", Managed: true}}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"count": len(results), "results": results})
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "user", "pass", "", false)
+	ctx := context.Background()
+
+	for range 2 {
+		id, err := c.ManagedCredentialTypeID(ctx, NamespaceMachine)
+		if err != nil {
+			t.Fatalf("ManagedCredentialTypeID failed: %s", err)
+		}
+		if id != 17 {
+			t.Errorf("Expected ID 17, got %d", id)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected the ID to be cached after 1 request, got %d requests", requests)
+	}
+
+	if id, err := c.ManagedCredentialTypeID(ctx, NamespaceSourceControl); err != nil || id != 23 {
+		t.Errorf("Expected ID 23, got %d (%v)", id, err)
+	}
+
+	_, err := c.ManagedCredentialTypeID(ctx, "vault")
+	if err == nil || !strings.Contains(err.Error(), `no managed credential type with namespace "vault"`) {
+		t.Errorf("Expected missing type error, got %v", err)
+	}
+}
+
+func TestCredentialTypeInputSchema(t *testing.T) {
+	ct := &CredentialType{Name: "API", Inputs: json.RawMessage(`{
+		"fields": [
+			{"id": "url", "label": "URL"},
+			{"id": "token", "label": "Token", "secret": true},
+			{"id": "verify", "label": "Verify", "type": "boolean"}
+		],
+		"required": ["url", "token"]
+	}`)}
+
+	schema, err := ct.InputSchema()
+	if err != nil {
+		t.Fatalf("InputSchema failed: %s", err)
+	}
+	if len(schema.Fields) != 3 || len(schema.Required) != 2 {
+		t.Fatalf("Unexpected schema %+v", schema)
+	}
+	if f := schema.Field("url"); f == nil || f.Type != "string" {
+		t.Errorf("Expected url to default to type string, got %+v", f)
+	}
+	if f := schema.Field("token"); f == nil || !f.Secret {
+		t.Errorf("Expected token to be secret, got %+v", f)
+	}
+	if schema.Field("missing") != nil {
+		t.Error("Expected nil for unknown field")
+	}
+
+	empty, err := (&CredentialType{}).InputSchema()
+	if err != nil || len(empty.Fields) != 0 {
+		t.Errorf("Expected empty schema, got %+v (%v)", empty, err)
+	}
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"strings"
+)
+
+// Endpoint provides CRUD operations for one AAP object type served from a
+// collection path such as "organizations/".
+type Endpoint[T any] struct {
+	client *Client
+	path   string
+	id     func(*T) int
+}
+
+// NewEndpoint returns an Endpoint for the collection at path, which is
+// relative to the client's APIPath unless it starts with a slash. id returns
+// the primary key of an object and is used to address it in Update.
+func NewEndpoint[T any](c *Client, path string, id func(*T) int) *Endpoint[T] {
+	return &Endpoint[T]{client: c, path: path, id: id}
+}
+
+func (e *Endpoint[T]) collectionPath() string {
+	if strings.HasPrefix(e.path, "/") {
+		return e.path
+	}
+	return e.client.APIPath + e.path
+}
+
+func (e *Endpoint[T]) objectPath(id int) string {
+	return fmt.Sprintf("%s%d/", e.collectionPath(), id)
+}
+
+// Get retrieves the object with the given ID.
+func (e *Endpoint[T]) Get(ctx context.Context, id int) (*T, error) {
+	return e.send(ctx, http.MethodGet, e.objectPath(id), nil)
+}
+
+// List streams objects matching opts, following pagination.
+func (e *Endpoint[T]) List(ctx context.Context, opts ListOptions) iter.Seq2[T, error] {
+	return list[T](ctx, e.client, e.collectionPath(), opts)
+}
+
+// Create creates obj and returns the object as stored by the controller.
+func (e *Endpoint[T]) Create(ctx context.Context, obj *T) (*T, error) {
+	return e.send(ctx, http.MethodPost, e.collectionPath(), obj)
+}
+
+// Update sends obj to the object identified by its ID and returns the
+// updated object.
+func (e *Endpoint[T]) Update(ctx context.Context, obj *T) (*T, error) {
+	return e.send(ctx, http.MethodPatch, e.objectPath(e.id(obj)), obj)
+}
+
+// Patch sends a partial update containing only the given fields, keyed by
+// API field name, and returns the updated object.
+func (e *Endpoint[T]) Patch(ctx context.Context, id int, fields map[string]interface{}) (*T, error) {
+	return e.send(ctx, http.MethodPatch, e.objectPath(id), fields)
+}
+
+// Delete deletes the object with the given ID.
+func (e *Endpoint[T]) Delete(ctx context.Context, id int) error {
+	_, err := e.client.doRequest(ctx, http.MethodDelete, e.objectPath(id), nil)
+	return err
+}
+
+func (e *Endpoint[T]) send(ctx context.Context, method, path string, body interface{}) (*T, error) {
+	resp, err := e.client.doRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj T
+	if err := json.Unmarshal(resp, &obj); err != nil {
+		return nil, fmt.Errorf("decoding %s %s response: %w", method, path, err)
+	}
+	return &obj, nil
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEndpointUpdateAndPatch(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" {
+			t.Errorf("Expected method PATCH, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/controller/v2/inventories/4/" {
+			t.Errorf("Expected path /api/controller/v2/inventories/4/, got %s", r.URL.Path)
+		}
+
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		json.NewEncoder(w).Encode(Inventory{ID: 4, Name: "Prod", Description: body["description"].(string)})
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "user", "pass", "", true)
+
+	inv, err := c.Inventories.Update(context.Background(), &Inventory{ID: 4, Name: "Prod", Description: "updated"})
+	if err != nil {
+		t.Fatalf("Update failed: %s", err)
+	}
+	if inv.Description != "updated" {
+		t.Errorf("Expected description 'updated', got %q", inv.Description)
+	}
+
+	inv, err = c.Inventories.Patch(context.Background(), 4, map[string]interface{}{"description": ""})
+	if err != nil {
+		t.Fatalf("Patch failed: %s", err)
+	}
+	if inv.Description != "" {
+		t.Errorf("Expected empty description, got %q", inv.Description)
+	}
+}
+
+func TestEndpointDelete(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected method DELETE, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/controller/v2/credential_types/12/" {
+			t.Errorf("Expected path /api/controller/v2/credential_types/12/, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "user", "pass", "", true)
+	if err := c.CredentialTypes.Delete(context.Background(), 12); err != nil {
+		t.Fatalf("Delete failed: %s", err)
+	}
+}
+
+func TestNewEndpointCustomType(t *testing.T) {
+	type host struct {
+		ID   int    `json:"id,omitempty"`
+		Name string `json:"name"`
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/controller/v2/hosts/" {
+			t.Errorf("Expected path /api/controller/v2/hosts/, got %s", r.URL.Path)
+		}
+		var h host
+		json.NewDecoder(r.Body).Decode(&h)
+		h.ID = 9
+		json.NewEncoder(w).Encode(h)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "user", "pass", "", true)
+	hosts := NewEndpoint(c, "/api/controller/v2/hosts/", func(h *host) int { return h.ID })
+
+	h, err := hosts.Create(context.Background(), &host{Name: "web01"})
+	if err != nil {
+		t.Fatalf("Create failed: %s", err)
+	}
+	if h.ID != 9 || h.Name != "web01" {
+		t.Errorf("Unexpected host %+v", h)
+	}
+}
@@ -0,0 +1,142 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// APIError is returned by the client when the controller answers with a
+// 4xx or 5xx status code.
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	// Body is the raw response body.
+	Body []byte
+	// Detail is the "detail" message AAP sends for errors that are not tied
+	// to a field, such as 404 and 403 responses.
+	Detail string
+	// Fields holds AAP field errors keyed by field name, decoded from bodies
+	// like {"name": ["This field is required."]}. Errors on nested objects
+	// such as credential inputs use dotted keys, e.g. "inputs.ssh_key_data".
+	Fields map[string][]string
+	// NonFieldErrors holds messages that apply to the object as a whole,
+	// sent by AAP under "__all__" or "non_field_errors".
+	NonFieldErrors []string
+}
+
+func newAPIError(method, url string, statusCode int, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: statusCode,
+		Method:     method,
+		URL:        url,
+		Body:       body,
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return e
+	}
+	for _, key := range sortedKeys(raw) {
+		value := raw[key]
+		switch key {
+		case "detail":
+			json.Unmarshal(value, &e.Detail)
+		case "__all__", "non_field_errors", "error":
+			e.NonFieldErrors = append(e.NonFieldErrors, decodeMessages(value)...)
+		default:
+			e.addFieldErrors(key, value)
+		}
+	}
+	return e
+}
+
+// addFieldErrors records the messages in value under field, descending into
+// nested objects.
+func (e *APIError) addFieldErrors(field string, value json.RawMessage) {
+	var nested map[string]json.RawMessage
+	if err := json.Unmarshal(value, &nested); err == nil {
+		for _, key := range sortedKeys(nested) {
+			e.addFieldErrors(field+"."+key, nested[key])
+		}
+		return
+	}
+
+	if e.Fields == nil {
+		e.Fields = map[string][]string{}
+	}
+	e.Fields[field] = append(e.Fields[field], decodeMessages(value)...)
+}
+
+// decodeMessages accepts the shapes AAP uses for error messages: a single
+// string or a list of strings. Anything else is returned verbatim.
+func decodeMessages(value json.RawMessage) []string {
+	var messages []string
+	if err := json.Unmarshal(value, &messages); err == nil {
+		return messages
+	}
+	var message string
+	if err := json.Unmarshal(value, &message); err == nil {
+		return []string{message}
+	}
+	return []string{string(value)}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// FieldNames returns the keys of Fields in sorted order, so that errors are
+// reported in a stable order.
+func (e *APIError) FieldNames() []string {
+	return sortedKeys(e.Fields)
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error: %s %s returned %d %s: %s", e.Method, e.URL, e.StatusCode, http.StatusText(e.StatusCode), e.message())
+}
+
+// message summarizes the response body for Error.
+func (e *APIError) message() string {
+	if e.Detail != "" {
+		return e.Detail
+	}
+	if len(e.Fields) == 0 && len(e.NonFieldErrors) == 0 {
+		return string(e.Body)
+	}
+
+	parts := append([]string{}, e.NonFieldErrors...)
+	for _, k := range e.FieldNames() {
+		parts = append(parts, fmt.Sprintf("%s: %s", k, strings.Join(e.Fields[k], " ")))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// IsNotFound reports whether err is an APIError with status 404.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is an APIError with status 409.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsForbidden reports whether err is an APIError with status 403.
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
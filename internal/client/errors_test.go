@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetOrganizationNotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"detail": "Not found."}`))
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "user", "pass", "", true)
+	_, err := c.Organizations.Get(context.Background(), 42)
+	if !IsNotFound(err) {
+		t.Fatalf("Expected not found error, got %v", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %T", err)
+	}
+	if apiErr.Method != "GET" {
+		t.Errorf("Expected method GET, got %s", apiErr.Method)
+	}
+	if apiErr.URL != ts.URL+"/api/controller/v2/organizations/42/" {
+		t.Errorf("Unexpected URL %s", apiErr.URL)
+	}
+	if apiErr.Detail != "Not found." {
+		t.Errorf("Expected detail 'Not found.', got %q", apiErr.Detail)
+	}
+	if IsConflict(err) {
+		t.Error("Expected IsConflict to be false for a 404")
+	}
+}
+
+func TestAPIErrorFields(t *testing.T) {
+	err := newAPIError("POST", "https://aap.example.com/api/controller/v2/job_templates/", http.StatusBadRequest,
+		[]byte(`{"playbook": ["Playbook not found for project."], "name": ["This field may not be blank."]}`))
+
+	if got := err.Fields["playbook"]; len(got) != 1 || got[0] != "Playbook not found for project." {
+		t.Errorf("Unexpected playbook errors %v", got)
+	}
+
+	want := "API error: POST https://aap.example.com/api/controller/v2/job_templates/ returned 400 Bad Request: " +
+		"name: This field may not be blank.; playbook: Playbook not found for project."
+	if err.Error() != want {
+		t.Errorf("Expected %q, got %q", want, err.Error())
+	}
+}
+
+func TestAPIErrorNonJSONBody(t *testing.T) {
+	err := newAPIError("GET", "https://aap.example.com/api/", http.StatusBadGateway, []byte("<html>Bad Gateway</html>"))
+	if err.Fields != nil || err.Detail != "" {
+		t.Errorf("Expected no parsed fields, got %v / %q", err.Fields, err.Detail)
+	}
+	if err.Error() != "API error: GET https://aap.example.com/api/ returned 502 Bad Gateway: <html>Bad Gateway</html>" {
+		t.Errorf("Unexpected message %q", err.Error())
+	}
+}
+
+func TestAPIErrorNestedAndNonFieldErrors(t *testing.T) {
+	err := newAPIError("PATCH", "https://aap.example.com/api/controller/v2/credentials/3/", http.StatusBadRequest,
+		[]byte(`{"inputs": {"ssh_key_data": ["Invalid certificate or key."]}, "limit": "Too long.", "__all__": ["Credential with this Name already exists."]}`))
+
+	if got := err.Fields["inputs.ssh_key_data"]; len(got) != 1 || got[0] != "Invalid certificate or key." {
+		t.Errorf("Unexpected inputs.ssh_key_data errors %v", got)
+	}
+	if got := err.Fields["limit"]; len(got) != 1 || got[0] != "Too long." {
+		t.Errorf("Unexpected limit errors %v", got)
+	}
+	if len(err.NonFieldErrors) != 1 || err.NonFieldErrors[0] != "Credential with this Name already exists." {
+		t.Errorf("Unexpected non-field errors %v", err.NonFieldErrors)
+	}
+	if _, ok := err.Fields["__all__"]; ok {
+		t.Error("Expected __all__ not to be reported as a field")
+	}
+}
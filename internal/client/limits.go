@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/time/rate"
+)
+
+// ConfigureLimits bounds the load the client puts on the server.
+// maxConcurrent caps the number of requests in flight and requestsPerSecond
+// spaces them out over time; zero disables either limit. The limits are
+// shared by every request made through c, and time spent waiting for them
+// counts against the caller's context.
+func (c *Client) ConfigureLimits(maxConcurrent int, requestsPerSecond float64) error {
+	if maxConcurrent < 0 {
+		return fmt.Errorf("max concurrent requests must not be negative, got %d", maxConcurrent)
+	}
+	if requestsPerSecond < 0 {
+		return fmt.Errorf("requests per second must not be negative, got %g", requestsPerSecond)
+	}
+
+	c.slots = nil
+	if maxConcurrent > 0 {
+		c.slots = make(chan struct{}, maxConcurrent)
+	}
+	c.limiter = nil
+	if requestsPerSecond > 0 {
+		// Allow bursts of up to one second's worth of requests, and at
+		// least one.
+		c.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), max(1, int(requestsPerSecond)))
+	}
+	return nil
+}
+
+// acquire waits until a request may be sent. The returned function releases
+// the concurrency slot and must be called once the response is read.
+func (c *Client) acquire(ctx context.Context) (func(), error) {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if c.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case c.slots <- struct{}{}:
+		return func() { <-c.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	var inFlight, peak int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`{"id": 1, "name": "Test Org"}`))
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "admin", "password", "", false)
+	if err := c.ConfigureLimits(2, 0); err != nil {
+		t.Fatalf("ConfigureLimits failed: %s", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Organizations.Get(context.Background(), 1); err != nil {
+				t.Errorf("Get failed: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak > 2 {
+		t.Errorf("Expected at most 2 concurrent requests, got %d", peak)
+	}
+}
+
+func TestRequestsPerSecond(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "name": "Test Org"}`))
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "admin", "password", "", false)
+	if err := c.ConfigureLimits(0, 20); err != nil {
+		t.Fatalf("ConfigureLimits failed: %s", err)
+	}
+
+	// The first 20 requests use the burst; the next 10 are spaced 50ms apart.
+	start := time.Now()
+	for i := 0; i < 30; i++ {
+		if _, err := c.Organizations.Get(context.Background(), 1); err != nil {
+			t.Fatalf("Get failed: %s", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("Expected requests to be rate limited, 30 took %s", elapsed)
+	}
+}
+
+func TestLimitsRespectContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "name": "Test Org"}`))
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "admin", "password", "", false)
+	if err := c.ConfigureLimits(1, 0); err != nil {
+		t.Fatalf("ConfigureLimits failed: %s", err)
+	}
+	// Hold the only slot.
+	release, err := c.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire failed: %s", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.Organizations.Get(ctx, 1); err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestConfigureLimitsRejectsNegative(t *testing.T) {
+	c := NewClient("https://aap.example.com", "", "", "", false)
+	if err := c.ConfigureLimits(-1, 0); err == nil {
+		t.Error("Expected error for negative max concurrent requests")
+	}
+	if err := c.ConfigureLimits(0, -1); err == nil {
+		t.Error("Expected error for negative requests per second")
+	}
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// ListOptions filters and pages list requests. Zero values are omitted from
+// the query string.
+type ListOptions struct {
+	// Name matches the object name exactly.
+	Name string
+	// OrganizationName matches the name of the owning organization.
+	OrganizationName string
+	// Search is AAP's free-text search across name and description.
+	Search string
+	// OrderBy sorts results, e.g. "name" or "-id".
+	OrderBy string
+	// PageSize is the number of results fetched per request.
+	PageSize int
+	// Filters holds any other AAP query filters, e.g. {"inventory__name": "prod"}.
+	Filters map[string]string
+}
+
+func (o ListOptions) query() url.Values {
+	q := url.Values{}
+	for k, v := range o.Filters {
+		q.Set(k, v)
+	}
+	if o.Name != "" {
+		q.Set("name", o.Name)
+	}
+	if o.OrganizationName != "" {
+		q.Set("organization__name", o.OrganizationName)
+	}
+	if o.Search != "" {
+		q.Set("search", o.Search)
+	}
+	if o.OrderBy != "" {
+		q.Set("order_by", o.OrderBy)
+	}
+	if o.PageSize > 0 {
+		q.Set("page_size", strconv.Itoa(o.PageSize))
+	}
+	return q
+}
+
+// page is AAP's paginated list envelope.
+type page[T any] struct {
+	Count   int     `json:"count"`
+	Next    *string `json:"next"`
+	Results []T     `json:"results"`
+}
+
+// list streams every object matching opts from the collection at path,
+// following the "next" link of each page until the last one. Pages are
+// only fetched as the caller consumes results; iteration stops at the first
+// error, which is yielded with a zero value.
+func list[T any](ctx context.Context, c *Client, path string, opts ListOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		next := path
+		if q := opts.query(); len(q) > 0 {
+			next += "?" + q.Encode()
+		}
+
+		for next != "" {
+			resp, err := c.doRequest(ctx, "GET", next, nil)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			var p page[T]
+			if err := json.Unmarshal(resp, &p); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range p.Results {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			next = ""
+			if p.Next != nil && *p.Next != "" {
+				// AAP returns "next" as a path, but some proxies rewrite it
+				// to an absolute URL; only the path and query are used.
+				u, err := url.Parse(*p.Next)
+				if err != nil {
+					yield(zero, err)
+					return
+				}
+				next = u.RequestURI()
+			}
+		}
+	}
+}
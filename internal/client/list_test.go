@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestListOrganizationsPaginates(t *testing.T) {
+	orgs := []Organization{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 3, Name: "c"}}
+	var requests int
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/api/controller/v2/organizations/" {
+			t.Errorf("Expected path /api/controller/v2/organizations/, got %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("order_by") != "name" {
+			t.Errorf("Expected order_by=name, got %q", q.Get("order_by"))
+		}
+		if q.Get("search") != "prod" {
+			t.Errorf("Expected search=prod, got %q", q.Get("search"))
+		}
+
+		size, _ := strconv.Atoi(q.Get("page_size"))
+		pageNum, _ := strconv.Atoi(q.Get("page"))
+		if pageNum == 0 {
+			pageNum = 1
+		}
+		start := (pageNum - 1) * size
+		end := min(start+size, len(orgs))
+
+		resp := map[string]interface{}{
+			"count":   len(orgs),
+			"results": orgs[start:end],
+			"next":    nil,
+		}
+		if end < len(orgs) {
+			resp["next"] = "/api/controller/v2/organizations/?order_by=name&page=" + strconv.Itoa(pageNum+1) + "&page_size=2&search=prod"
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "user", "pass", "", true)
+
+	var names []string
+	for org, err := range c.Organizations.List(context.Background(), ListOptions{Search: "prod", OrderBy: "name", PageSize: 2}) {
+		if err != nil {
+			t.Fatalf("ListOrganizations failed: %s", err)
+		}
+		names = append(names, org.Name)
+	}
+
+	if len(names) != 3 || names[0] != "a" || names[2] != "c" {
+		t.Errorf("Unexpected results %v", names)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+}
+
+func TestListStopsFetchingWhenCallerBreaks(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("name") != "Demo Project" {
+			t.Errorf("Expected name filter, got %q", r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"count":   10,
+			"next":    "/api/controller/v2/projects/?name=Demo+Project&page=2",
+			"results": []Project{{ID: 7, Name: "Demo Project"}},
+		})
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "user", "pass", "", true)
+	for p, err := range c.Projects.List(context.Background(), ListOptions{Name: "Demo Project"}) {
+		if err != nil {
+			t.Fatalf("ListProjects failed: %s", err)
+		}
+		if p.ID != 7 {
+			t.Errorf("Expected ID 7, got %d", p.ID)
+		}
+		break
+	}
+
+	if requests != 1 {
+		t.Errorf("Expected 1 request, got %d", requests)
+	}
+}
+
+func TestListYieldsErrors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"detail": "You do not have permission to perform this action."}`))
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "user", "pass", "", true)
+	for _, err := range c.Inventories.List(context.Background(), ListOptions{OrganizationName: "Default"}) {
+		if !IsForbidden(err) {
+			t.Errorf("Expected forbidden error, got %v", err)
+		}
+	}
+}
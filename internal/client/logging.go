@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const redacted = "***"
+
+// sensitiveKeys are JSON fields and headers, lower-cased, whose values are
+// masked in logs. Keys containing "password" or "secret" are always masked.
+var sensitiveKeys = map[string]bool{
+	"ssh_key_data":   true,
+	"ssh_key_unlock": true,
+	"token":          true,
+	"security_token": true,
+	"authorization":  true,
+	"cookie":         true,
+	"set-cookie":     true,
+	"x-csrftoken":    true,
+}
+
+func isSensitiveKey(k string) bool {
+	k = strings.ToLower(k)
+	return sensitiveKeys[k] || strings.Contains(k, "password") || strings.Contains(k, "secret")
+}
+
+// logContext masks the client's own credentials wherever they appear in log
+// fields, as a backstop to key-based redaction.
+func (c *Client) logContext(ctx context.Context) context.Context {
+	var secrets []string
+	for _, s := range []string{c.Password, c.Token} {
+		if s != "" {
+			secrets = append(secrets, s)
+		}
+	}
+	if len(secrets) > 0 {
+		ctx = tflog.MaskAllFieldValuesStrings(ctx, secrets...)
+	}
+	return ctx
+}
+
+// redactBody returns body with the values of sensitive fields masked at any
+// depth. Bodies that are not JSON are returned as they are.
+func redactBody(body []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+	b, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return string(body)
+	}
+	return string(b)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			if isSensitiveKey(k) && val != nil && val != "" {
+				v[k] = redacted
+			} else {
+				v[k] = redactValue(val)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	}
+	return v
+}
+
+// redactHeaders flattens h for logging with sensitive values masked.
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		if isSensitiveKey(k) {
+			out[k] = redacted
+		} else {
+			out[k] = strings.Join(v, ", ")
+		}
+	}
+	return out
+}
+
+// requestID returns the ID the gateway or controller assigned to a request,
+// for correlating provider logs with server logs.
+func requestID(resp *http.Response) string {
+	if id := resp.Header.Get("X-Request-Id"); id != "" {
+		return id
+	}
+	return resp.Header.Get("X-API-Request-Id")
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedactBody(t *testing.T) {
+	body := []byte(`{"name": "Machine", "inputs": {"username": "deploy", "password": "hunter2", "ssh_key_data": "-----BEGIN KEY-----", "become_password": "root-pw"}, "results": [{"token": "abc"}]}`)
+
+	got := redactBody(body)
+	for _, secret := range []string{"hunter2", "BEGIN KEY", "root-pw", "abc"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("Expected %q to be redacted, got %s", secret, got)
+		}
+	}
+	if !strings.Contains(got, "deploy") || !strings.Contains(got, "Machine") {
+		t.Errorf("Expected non-sensitive values to be kept, got %s", got)
+	}
+
+	if got := redactBody([]byte("<html>Bad Gateway</html>")); got != "<html>Bad Gateway</html>" {
+		t.Errorf("Expected non-JSON body unchanged, got %s", got)
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Bearer secret-token")
+	h.Set("X-CSRFToken", "csrf")
+	h.Set("Content-Type", "application/json")
+
+	got := redactHeaders(h)
+	if got["Authorization"] != redacted || got["X-Csrftoken"] != redacted {
+		t.Errorf("Expected credentials to be redacted, got %v", got)
+	}
+	if got["Content-Type"] != "application/json" {
+		t.Errorf("Expected Content-Type to be kept, got %v", got)
+	}
+}
+
+func TestRequestLogging(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-42")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": 5, "name": "Machine", "inputs": {"username": "deploy", "password": "$encrypted$"}}`))
+	}))
+	defer ts.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	c := NewClient(ts.URL, "admin", "admin-password", "", false)
+	_, err := c.Credentials.Create(ctx, &Credential{
+		Name:   "Machine",
+		Inputs: CredentialInputs{"username": "deploy", "password": "hunter2"},
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %s", err)
+	}
+
+	if s := output.String(); strings.Contains(s, "hunter2") || strings.Contains(s, "admin-password") {
+		t.Errorf("Expected secrets to be redacted from logs, got %s", s)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("Decoding logs failed: %s", err)
+	}
+	var found bool
+	for _, e := range entries {
+		if e["@message"] == "Received AAP API response" {
+			found = true
+			if e["request_id"] != "req-42" || e["status"] != float64(201) {
+				t.Errorf("Expected status 201 and request_id req-42, got %v", e)
+			}
+		}
+	}
+	if !found {
+		t.Error("Expected a response log entry")
+	}
+}
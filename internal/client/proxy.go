@@ -0,0 +1,45 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ConfigureProxy routes requests through the proxy at proxyURL, except for
+// hosts matched by noProxy, a comma-separated list in the NO_PROXY format.
+// Proxy credentials may be given as user info in proxyURL. An empty
+// proxyURL keeps the proxy settings from the environment.
+func (c *Client) ConfigureProxy(proxyURL, noProxy string) error {
+	if proxyURL == "" {
+		if noProxy != "" {
+			cfg := httpproxy.FromEnvironment()
+			cfg.NoProxy = noProxy
+			c.setProxy(cfg)
+		}
+		return nil
+	}
+
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid proxy URL %q: expected scheme and host, e.g. http://proxy.example.com:3128", proxyURL)
+	}
+	c.setProxy(&httpproxy.Config{
+		HTTPProxy:  proxyURL,
+		HTTPSProxy: proxyURL,
+		NoProxy:    noProxy,
+	})
+	return nil
+}
+
+func (c *Client) setProxy(cfg *httpproxy.Config) {
+	proxyFunc := cfg.ProxyFunc()
+	c.transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConfigureProxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host != "aap.example.invalid" {
+			t.Errorf("Expected proxied request for aap.example.invalid, got %s", r.URL.Host)
+		}
+		want := "Basic " + base64.StdEncoding.EncodeToString([]byte("proxyuser:proxypass"))
+		if got := r.Header.Get("Proxy-Authorization"); got != want {
+			t.Errorf("Expected Proxy-Authorization %q, got %q", want, got)
+		}
+		json.NewEncoder(w).Encode(Organization{ID: 1, Name: "Test Org"})
+	}))
+	defer proxy.Close()
+
+	c := NewClient("http://aap.example.invalid", "user", "pass", "", false)
+	c.Retry.MaxRetries = 0
+	proxyURL := "http://proxyuser:proxypass@" + proxy.Listener.Addr().String()
+	if err := c.ConfigureProxy(proxyURL, ""); err != nil {
+		t.Fatalf("ConfigureProxy failed: %s", err)
+	}
+
+	org, err := c.Organizations.Get(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Get through proxy failed: %s", err)
+	}
+	if org.Name != "Test Org" {
+		t.Errorf("Expected name 'Test Org', got %s", org.Name)
+	}
+}
+
+func TestConfigureProxyNoProxy(t *testing.T) {
+	c := NewClient("https://aap.example.com", "", "", "", false)
+	if err := c.ConfigureProxy("http://proxy.example.com:3128", "aap.example.com,.internal"); err != nil {
+		t.Fatalf("ConfigureProxy failed: %s", err)
+	}
+
+	for host, proxied := range map[string]bool{
+		"https://aap.example.com/api/":   false,
+		"https://gw.internal/api/":       false,
+		"https://other.example.com/api/": true,
+	} {
+		req, _ := http.NewRequest("GET", host, nil)
+		u, err := c.transport.Proxy(req)
+		if err != nil {
+			t.Fatalf("Proxy(%s) failed: %s", host, err)
+		}
+		if (u != nil) != proxied {
+			t.Errorf("%s: expected proxied=%t, got proxy %v", host, proxied, u)
+		}
+	}
+
+	if err := c.ConfigureProxy("proxy.example.com", ""); err == nil {
+		t.Error("Expected error for proxy URL without scheme")
+	}
+}
+
+func TestRequestHeadersAndUserAgent(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("User-Agent"); got != "terraform-provider-aap/1.2.3" {
+			t.Errorf("Expected User-Agent terraform-provider-aap/1.2.3, got %q", got)
+		}
+		if got := r.Header.Get("X-Tenant"); got != "blue" {
+			t.Errorf("Expected X-Tenant blue, got %q", got)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Expected custom headers not to override Authorization, got %q", got)
+		}
+		json.NewEncoder(w).Encode(Organization{ID: 1})
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "", "", "secret", false)
+	c.UserAgent = "terraform-provider-aap/1.2.3"
+	c.Headers = http.Header{}
+	c.Headers.Set("x-tenant", "blue")
+	c.Headers.Set("Authorization", "Bearer other")
+
+	if _, err := c.Organizations.Get(context.Background(), 1); err != nil {
+		t.Fatalf("Get failed: %s", err)
+	}
+}
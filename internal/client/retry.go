@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how doRequest retries transient failures such as
+// connection resets, Platform Gateway 502/503/504 responses and 429 rate
+// limiting.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt. Zero
+	// disables retries.
+	MaxRetries int
+	// WaitMin is the base delay used for exponential backoff.
+	WaitMin time.Duration
+	// WaitMax caps the delay between attempts, including delays requested
+	// by the server through Retry-After.
+	WaitMax time.Duration
+}
+
+// DefaultRetryPolicy returns the policy used when the provider does not
+// override it.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 4,
+		WaitMin:    1 * time.Second,
+		WaitMax:    30 * time.Second,
+	}
+}
+
+type noRetriesKey struct{}
+
+// WithoutRetries returns a context under which requests are attempted once,
+// whatever the client's RetryPolicy, for calls such as token revocation at
+// shutdown that should not wait out a backoff.
+func WithoutRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetriesKey{}, true)
+}
+
+// shouldRetry reports whether the attempt that produced resp/err should be
+// repeated. Requests that may have reached the controller are only replayed
+// for idempotent methods; POSTs are retried only when the controller
+// certainly did not process them.
+func (p RetryPolicy) shouldRetry(ctx context.Context, method string, attempt int, resp *http.Response, err error) bool {
+	if attempt >= p.MaxRetries || ctx.Err() != nil || ctx.Value(noRetriesKey{}) != nil {
+		return false
+	}
+
+	if err != nil {
+		if isIdempotent(method) {
+			return true
+		}
+		return isDialError(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+	return false
+}
+
+// backoff returns how long to wait before the next attempt. A Retry-After
+// header on resp takes precedence over exponential backoff.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(d, p.WaitMax)
+		}
+	}
+
+	wait := p.WaitMax
+	if attempt < 32 {
+		if w := p.WaitMin << attempt; w > 0 && w < p.WaitMax {
+			wait = w
+		}
+	}
+
+	// Equal jitter: keep half of the delay and randomise the rest so that
+	// parallel resources do not retry in lockstep.
+	half := wait / 2
+	return half + rand.N(half+1)
+}
+
+// isIdempotent reports whether a request with this method can be replayed
+// safely. PATCH is included because the client always sends absolute field
+// values, so applying the same body twice yields the same object.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodPatch:
+		return true
+	}
+	return false
+}
+
+// isDialError reports whether err happened while establishing the
+// connection, before any part of the request was sent.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxRetries: 3, WaitMin: time.Millisecond, WaitMax: 10 * time.Millisecond}
+}
+
+func TestRetryTransientGet(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(Organization{ID: 1, Name: "Test Org"})
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "user", "pass", "", true)
+	c.Retry = testRetryPolicy()
+
+	org, err := c.Organizations.Get(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetOrganization failed: %s", err)
+	}
+	if org.Name != "Test Org" {
+		t.Errorf("Expected name 'Test Org', got %s", org.Name)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetryGivesUpAfterMaxRetries(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "user", "pass", "", true)
+	c.Retry = testRetryPolicy()
+
+	if _, err := c.Organizations.Get(context.Background(), 1); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if calls != 4 {
+		t.Errorf("Expected 4 calls, got %d", calls)
+	}
+}
+
+func TestRetryDisabledByContext(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "user", "pass", "", true)
+	c.Retry = testRetryPolicy()
+
+	if err := c.Organizations.Delete(WithoutRetries(context.Background()), 1); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+}
+
+func TestRetryDoesNotReplayPostOnGatewayError(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "user", "pass", "", true)
+	c.Retry = testRetryPolicy()
+
+	if _, err := c.Organizations.Create(context.Background(), &Organization{Name: "Test Org"}); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+}
+
+func TestRetryPostOnRateLimit(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var org Organization
+		json.NewDecoder(r.Body).Decode(&org)
+		if org.Name != "Test Org" {
+			t.Errorf("Expected replayed body with name 'Test Org', got %q", org.Name)
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		org.ID = 5
+		json.NewEncoder(w).Encode(org)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "user", "pass", "", true)
+	c.Retry = testRetryPolicy()
+
+	org, err := c.Organizations.Create(context.Background(), &Organization{Name: "Test Org"})
+	if err != nil {
+		t.Fatalf("CreateOrganization failed: %s", err)
+	}
+	if org.ID != 5 {
+		t.Errorf("Expected ID 5, got %d", org.ID)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{MaxRetries: 10, WaitMin: time.Second, WaitMax: 8 * time.Second}
+
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 8 * time.Second} {
+		got := p.backoff(attempt, nil)
+		if got < want/2 || got > want {
+			t.Errorf("attempt %d: expected backoff in [%s, %s], got %s", attempt, want/2, want, got)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	if got := p.backoff(0, resp); got != 3*time.Second {
+		t.Errorf("Expected Retry-After of 3s, got %s", got)
+	}
+
+	resp.Header.Set("Retry-After", "120")
+	if got := p.backoff(0, resp); got != p.WaitMax {
+		t.Errorf("Expected Retry-After capped at %s, got %s", p.WaitMax, got)
+	}
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	gatewayLoginPath = "/api/gateway/v1/login/"
+	// controllerLoginPath serves session logins on controllers without a
+	// gateway.
+	controllerLoginPath = "/api/login/"
+)
+
+// session tracks a Platform Gateway session login. generation is bumped on
+// every successful login so that concurrent requests that all see the same
+// expired session trigger a single re-login.
+type session struct {
+	mu         sync.Mutex
+	generation int
+}
+
+func (s *session) current() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.generation
+}
+
+// Login authenticates with the client's username and password against the
+// Platform Gateway login endpoint, or the controller's when there is no
+// gateway. Later requests use the session cookie, send the CSRF token on
+// unsafe methods, and log in again when the session expires.
+func (c *Client) Login(ctx context.Context) error {
+	if c.Username == "" || c.Password == "" {
+		return errors.New("username and password are required for session login")
+	}
+	if c.HTTP.Jar == nil {
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			return err
+		}
+		c.HTTP.Jar = jar
+	}
+	c.session = &session{}
+	return c.login(ctx, 0)
+}
+
+// login performs the login exchange unless another request already renewed
+// the session since generation was observed.
+func (c *Client) login(ctx context.Context, generation int) error {
+	c.session.mu.Lock()
+	defer c.session.mu.Unlock()
+	if c.session.generation != generation {
+		return nil
+	}
+
+	loginURL := c.Host + controllerLoginPath
+	if c.behindGateway() {
+		loginURL = c.Host + gatewayLoginPath
+	}
+
+	ctx = c.logContext(ctx)
+	tflog.Debug(ctx, "Logging in to AAP", map[string]interface{}{"url": loginURL, "username": c.Username})
+
+	// The login form sets the CSRF cookie that must accompany the POST.
+	_, _, err := c.loginRequest(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, loginURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		c.setCommonHeaders(req)
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{"username": {c.Username}, "password": {c.Password}}
+	resp, body, err := c.loginRequest(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, loginURL, strings.NewReader(form.Encode()))
+		if err != nil {
+			return nil, err
+		}
+		c.setCommonHeaders(req)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Referer", loginURL)
+		if token := c.cookie("csrftoken"); token != "" {
+			req.Header.Set("X-CSRFToken", token)
+		}
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		return newAPIError(http.MethodPost, loginURL, resp.StatusCode, body)
+	}
+	if !c.hasSessionCookie() {
+		return fmt.Errorf("login as %s did not return a session cookie", c.Username)
+	}
+
+	c.session.generation++
+	return nil
+}
+
+// loginRequest sends a request of the login exchange, built afresh by newReq
+// for each attempt, under the same limits, retry policy and logging as API
+// requests. The form body is not logged, since it holds the password.
+func (c *Client) loginRequest(ctx context.Context, newReq func() (*http.Request, error)) (*http.Response, []byte, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := newReq()
+		if err != nil {
+			return nil, nil, err
+		}
+		resp, body, err := c.send(ctx, req, req.URL.Path, attempt, nil)
+		if c.Retry.shouldRetry(ctx, req.Method, attempt, resp, err) {
+			if err := sleepContext(ctx, c.Retry.backoff(attempt, resp)); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		return resp, body, err
+	}
+}
+
+// cookie returns the value of the named cookie the jar holds for Host.
+func (c *Client) cookie(name string) string {
+	u, err := url.Parse(c.Host + "/")
+	if err != nil || c.HTTP.Jar == nil {
+		return ""
+	}
+	for _, ck := range c.HTTP.Jar.Cookies(u) {
+		if ck.Name == name {
+			return ck.Value
+		}
+	}
+	return ""
+}
+
+// hasSessionCookie reports whether the jar holds a session cookie for Host.
+// The gateway names it gateway_sessionid, older controllers awx_sessionid.
+func (c *Client) hasSessionCookie() bool {
+	u, err := url.Parse(c.Host + "/")
+	if err != nil {
+		return false
+	}
+	for _, ck := range c.HTTP.Jar.Cookies(u) {
+		if strings.HasSuffix(ck.Name, "sessionid") {
+			return true
+		}
+	}
+	return false
+}
+
+// sessionExpired reports whether resp rejected the session cookie, either
+// as unauthenticated or as failing the CSRF check after the cookie rotated.
+func sessionExpired(resp *http.Response, body []byte) bool {
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		return true
+	case http.StatusForbidden:
+		detail := newAPIError("", "", resp.StatusCode, body).Detail
+		return strings.Contains(detail, "CSRF") || strings.Contains(detail, "credentials were not provided")
+	}
+	return false
+}
+
+// isSafeMethod reports whether method is exempt from CSRF checks.
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
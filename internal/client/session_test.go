@@ -0,0 +1,168 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// newSessionServer returns a server that issues gateway_sessionid cookies
+// from the login endpoint and accepts only the most recent one.
+func newSessionServer(t *testing.T, logins *int, session *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("Expected no Authorization header with session auth, got %q", r.Header.Get("Authorization"))
+		}
+		if r.URL.Path == gatewayLoginPath {
+			switch r.Method {
+			case "GET":
+				http.SetCookie(w, &http.Cookie{Name: "csrftoken", Value: "csrf-1", Path: "/"})
+			case "POST":
+				if r.Header.Get("X-CSRFToken") != "csrf-1" {
+					t.Errorf("Expected X-CSRFToken csrf-1 on login, got %q", r.Header.Get("X-CSRFToken"))
+				}
+				if r.FormValue("username") != "admin" || r.FormValue("password") != "secret" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				*logins++
+				*session = fmt.Sprintf("s%d", *logins)
+				http.SetCookie(w, &http.Cookie{Name: "gateway_sessionid", Value: *session, Path: "/"})
+				w.WriteHeader(http.StatusNoContent)
+			}
+			return
+		}
+
+		if ck, err := r.Cookie("gateway_sessionid"); err != nil || ck.Value != *session {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"detail": "Authentication credentials were not provided."}`))
+			return
+		}
+		if r.Method == "POST" && r.Header.Get("X-CSRFToken") != "csrf-1" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"detail": "CSRF Failed: CSRF token missing."}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(Organization{ID: 1, Name: "Test Org"})
+	}))
+}
+
+func TestSessionLogin(t *testing.T) {
+	var logins int
+	var session string
+	ts := newSessionServer(t, &logins, &session)
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "admin", "secret", "", false)
+	if err := c.Login(context.Background()); err != nil {
+		t.Fatalf("Login failed: %s", err)
+	}
+	if _, err := c.Organizations.Get(context.Background(), 1); err != nil {
+		t.Fatalf("Get failed: %s", err)
+	}
+	if _, err := c.Organizations.Create(context.Background(), &Organization{Name: "Test Org"}); err != nil {
+		t.Fatalf("Create failed: %s", err)
+	}
+	if logins != 1 {
+		t.Errorf("Expected 1 login, got %d", logins)
+	}
+}
+
+func TestSessionReauthenticatesWhenExpired(t *testing.T) {
+	var logins int
+	var session string
+	ts := newSessionServer(t, &logins, &session)
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "admin", "secret", "", false)
+	if err := c.Login(context.Background()); err != nil {
+		t.Fatalf("Login failed: %s", err)
+	}
+
+	// Expire the session server-side.
+	session = "expired"
+
+	org, err := c.Organizations.Get(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Get failed: %s", err)
+	}
+	if org.Name != "Test Org" {
+		t.Errorf("Expected Test Org, got %s", org.Name)
+	}
+	if logins != 2 {
+		t.Errorf("Expected 2 logins, got %d", logins)
+	}
+}
+
+func TestSessionLoginInvalidCredentials(t *testing.T) {
+	var logins int
+	var session string
+	ts := newSessionServer(t, &logins, &session)
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "admin", "wrong", "", false)
+	err := c.Login(context.Background())
+	if err == nil {
+		t.Fatal("Expected login error")
+	}
+	if apiErr, ok := err.(*APIError); !ok || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected 401 APIError, got %v", err)
+	}
+}
+
+func TestSessionLoginUsesRetryAndLogging(t *testing.T) {
+	var logins int
+	var session string
+	ts := newSessionServer(t, &logins, &session)
+	defer ts.Close()
+
+	// Fail the first login attempt the way a gateway does while restarting.
+	var failed bool
+	ts.Config.Handler = func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !failed && r.URL.Path == gatewayLoginPath {
+				failed = true
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}(ts.Config.Handler)
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	c := NewClient(ts.URL, "admin", "secret", "", false)
+	c.Retry = testRetryPolicy()
+	if err := c.Login(ctx); err != nil {
+		t.Fatalf("Login failed: %s", err)
+	}
+	if logins != 1 {
+		t.Errorf("Expected 1 login, got %d", logins)
+	}
+	if strings.Contains(output.String(), "secret") {
+		t.Errorf("Expected the password to be redacted from logs, got %s", output.String())
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("Decoding logs failed: %s", err)
+	}
+	var attempts int
+	for _, e := range entries {
+		if e["@message"] == "Sending AAP API request" && e["path"] == gatewayLoginPath {
+			attempts++
+		}
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 logged login requests, got %d", attempts)
+	}
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+)
+
+// TLSOptions configures how the client verifies the controller certificate
+// and authenticates itself with a client certificate.
+type TLSOptions struct {
+	// CACert is a PEM bundle of CA certificates trusted in addition to the
+	// system roots.
+	CACert []byte
+	// ClientCert and ClientKey are a PEM certificate and private key used
+	// for mutual TLS. Both must be set together.
+	ClientCert []byte
+	ClientKey  []byte
+	// MinVersion is the minimum TLS version, e.g. tls.VersionTLS12. Zero
+	// keeps the Go default.
+	MinVersion uint16
+}
+
+// ConfigureTLS applies opts to the client's transport.
+func (c *Client) ConfigureTLS(opts TLSOptions) error {
+	cfg := c.transport.TLSClientConfig
+	if cfg == nil {
+		cfg = &tls.Config{}
+		c.transport.TLSClientConfig = cfg
+	}
+
+	if len(opts.CACert) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(opts.CACert) {
+			return errors.New("no valid PEM certificates found in CA bundle")
+		}
+		cfg.RootCAs = pool
+	}
+
+	if len(opts.ClientCert) > 0 || len(opts.ClientKey) > 0 {
+		if len(opts.ClientCert) == 0 || len(opts.ClientKey) == 0 {
+			return errors.New("client certificate and client key must be set together")
+		}
+		cert, err := tls.X509KeyPair(opts.ClientCert, opts.ClientKey)
+		if err != nil {
+			return fmt.Errorf("loading client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if opts.MinVersion != 0 {
+		cfg.MinVersion = opts.MinVersion
+	}
+	return nil
+}
+
+// ParseTLSVersion converts a version string such as "1.2" to the matching
+// crypto/tls constant.
+func ParseTLSVersion(v string) (uint16, error) {
+	switch v {
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	}
+	return 0, fmt.Errorf("unsupported TLS version %q, expected one of 1.0, 1.1, 1.2, 1.3", v)
+}
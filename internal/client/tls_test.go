@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTLSTestServer(t *testing.T, clientCAs *x509.CertPool) *httptest.Server {
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Organization{ID: 1, Name: "Test Org"})
+	}))
+	if clientCAs != nil {
+		ts.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	}
+	ts.StartTLS()
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func serverCAPEM(ts *httptest.Server) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+}
+
+func generateClientCert(t *testing.T) (certPEM, keyPEM []byte, cert *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terraform"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err = x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, cert
+}
+
+func TestConfigureTLSCACert(t *testing.T) {
+	ts := newTLSTestServer(t, nil)
+
+	c := NewClient(ts.URL, "user", "pass", "", false)
+	c.Retry.MaxRetries = 0
+	if _, err := c.Organizations.Get(context.Background(), 1); err == nil {
+		t.Fatal("Expected certificate verification error without CA bundle")
+	}
+
+	if err := c.ConfigureTLS(TLSOptions{CACert: serverCAPEM(ts), MinVersion: tls.VersionTLS12}); err != nil {
+		t.Fatalf("ConfigureTLS failed: %s", err)
+	}
+	if _, err := c.Organizations.Get(context.Background(), 1); err != nil {
+		t.Fatalf("Get with CA bundle failed: %s", err)
+	}
+}
+
+func TestConfigureTLSClientCertificate(t *testing.T) {
+	certPEM, keyPEM, cert := generateClientCert(t)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	ts := newTLSTestServer(t, pool)
+
+	c := NewClient(ts.URL, "user", "pass", "", false)
+	c.Retry.MaxRetries = 0
+	if err := c.ConfigureTLS(TLSOptions{CACert: serverCAPEM(ts)}); err != nil {
+		t.Fatalf("ConfigureTLS failed: %s", err)
+	}
+	if _, err := c.Organizations.Get(context.Background(), 1); err == nil {
+		t.Fatal("Expected handshake failure without client certificate")
+	}
+
+	if err := c.ConfigureTLS(TLSOptions{ClientCert: certPEM, ClientKey: keyPEM}); err != nil {
+		t.Fatalf("ConfigureTLS failed: %s", err)
+	}
+	if _, err := c.Organizations.Get(context.Background(), 1); err != nil {
+		t.Fatalf("Get with client certificate failed: %s", err)
+	}
+}
+
+func TestConfigureTLSErrors(t *testing.T) {
+	c := NewClient("https://aap.example.com", "", "", "", false)
+
+	if err := c.ConfigureTLS(TLSOptions{CACert: []byte("not a certificate")}); err == nil {
+		t.Error("Expected error for invalid CA bundle")
+	}
+	if err := c.ConfigureTLS(TLSOptions{ClientCert: []byte("cert")}); err == nil {
+		t.Error("Expected error for client certificate without key")
+	}
+	if _, err := ParseTLSVersion("1.4"); err == nil {
+		t.Error("Expected error for unsupported TLS version")
+	}
+}
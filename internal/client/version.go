@@ -0,0 +1,173 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ServerVersion is the controller version reported by the API, e.g. 4.6.2 for
+// the controller shipped with AAP 2.5 or 24.6.1 for AWX.
+type ServerVersion struct {
+	Major, Minor, Patch int
+	// AWX is set for upstream AWX, whose version numbers are unrelated to
+	// those of the automation controller.
+	AWX bool
+	// ProductUnknown is set when the server did not say whether it runs AWX
+	// or automation controller. Features are then not gated, since the
+	// version alone cannot be compared to either product's bounds.
+	ProductUnknown bool
+	// Raw is the version string as reported.
+	Raw string
+}
+
+// ParseServerVersion parses the leading numeric components of s. Suffixes such
+// as "4.6.0.dev12+g1a2b3c" are ignored.
+func ParseServerVersion(s string) (ServerVersion, error) {
+	v := ServerVersion{Raw: s}
+	rest := s
+	for i, n := range []*int{&v.Major, &v.Minor, &v.Patch} {
+		end := strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsDigit(r) })
+		if end < 0 {
+			end = len(rest)
+		}
+		if end == 0 {
+			if i == 0 {
+				return v, fmt.Errorf("invalid server version %q", s)
+			}
+			break
+		}
+		num, err := strconv.Atoi(rest[:end])
+		if err != nil {
+			return v, fmt.Errorf("invalid server version %q: %w", s, err)
+		}
+		*n = num
+		if rest = rest[end:]; !strings.HasPrefix(rest, ".") {
+			break
+		}
+		rest = rest[1:]
+	}
+	return v, nil
+}
+
+// Compare returns -1, 0 or 1 as v is older than, equal to or newer than
+// other, ignoring AWX.
+func (v ServerVersion) Compare(other ServerVersion) int {
+	for _, d := range [][2]int{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if d[0] != d[1] {
+			if d[0] < d[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func (v ServerVersion) String() string {
+	if v.ProductUnknown {
+		return "version " + v.Raw
+	}
+	if v.AWX {
+		return "AWX " + v.Raw
+	}
+	return "automation controller " + v.Raw
+}
+
+// serverInfo is the subset of the config/ and ping/ responses used to
+// identify the server.
+type serverInfo struct {
+	Version     string `json:"version"`
+	LicenseInfo struct {
+		LicenseType string `json:"license_type"`
+	} `json:"license_info"`
+}
+
+// DetectVersion sets Version from the config/ endpoint, falling back to the
+// unauthenticated ping/ endpoint when config/ is not readable. ping/ has no
+// license_info, so the product is then told from the version alone: AWX
+// 10 and later use version numbers automation controller never reached,
+// while older AWX and Tower releases overlap and are left unknown.
+func (c *Client) DetectVersion(ctx context.Context) error {
+	ping := false
+	resp, err := c.doRequest(ctx, http.MethodGet, c.APIPath+"config/", nil)
+	if err != nil {
+		if resp, err = c.doRequest(ctx, http.MethodGet, c.APIPath+"ping/", nil); err != nil {
+			return err
+		}
+		ping = true
+	}
+
+	var info serverInfo
+	if err := json.Unmarshal(resp, &info); err != nil {
+		return fmt.Errorf("decoding server version: %w", err)
+	}
+	v, err := ParseServerVersion(info.Version)
+	if err != nil {
+		return err
+	}
+	switch {
+	case !ping:
+		v.AWX = info.LicenseInfo.LicenseType == "open"
+	case v.Major >= 10:
+		v.AWX = true
+	default:
+		v.ProductUnknown = true
+	}
+	c.Version = &v
+	return nil
+}
+
+// VersionRange bounds the versions providing a feature. Empty bounds are
+// open.
+type VersionRange struct {
+	// Since is the first version with the feature.
+	Since string
+	// Until is the first version without it.
+	Until string
+}
+
+// Feature is an API capability available only on some server versions.
+// Controller bounds apply to Tower and automation controller, AWX bounds to
+// upstream AWX.
+type Feature struct {
+	// Name is the subject of unavailability messages.
+	Name       string
+	Controller VersionRange
+	AWX        VersionRange
+}
+
+// FeatureInventoryScripts is the inventory_scripts/ endpoint, removed in
+// automation controller 4.0 (AAP 2.0) and AWX 18.
+var FeatureInventoryScripts = Feature{
+	Name:       "The inventory_scripts API",
+	Controller: VersionRange{Until: "4.0.0"},
+	AWX:        VersionRange{Until: "18.0.0"},
+}
+
+// Supports returns an error describing why f is unavailable on the server,
+// or nil when it is available or the server version or product is unknown.
+func (c *Client) Supports(f Feature) error {
+	if c.Version == nil || c.Version.ProductUnknown {
+		return nil
+	}
+	r, product := f.Controller, "automation controller"
+	if c.Version.AWX {
+		r, product = f.AWX, "AWX"
+	}
+	if r.Since != "" {
+		if since, err := ParseServerVersion(r.Since); err == nil && c.Version.Compare(since) < 0 {
+			return fmt.Errorf("%s requires %s >= %s, but the server runs %s", f.Name, product, r.Since, c.Version)
+		}
+	}
+	if r.Until != "" {
+		if until, err := ParseServerVersion(r.Until); err == nil && c.Version.Compare(until) >= 0 {
+			return fmt.Errorf("%s is not available on %s; it was removed in %s %s", f.Name, c.Version, product, r.Until)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseServerVersion(t *testing.T) {
+	tests := []struct {
+		in                  string
+		major, minor, patch int
+	}{
+		{"4.6.2", 4, 6, 2},
+		{"24.6.1", 24, 6, 1},
+		{"3.8", 3, 8, 0},
+		{"4.6.0.dev12+g1a2b3c", 4, 6, 0},
+		{"4.5.0-rc1", 4, 5, 0},
+	}
+	for _, tt := range tests {
+		v, err := ParseServerVersion(tt.in)
+		if err != nil {
+			t.Errorf("ParseServerVersion(%q) failed: %s", tt.in, err)
+			continue
+		}
+		if v.Major != tt.major || v.Minor != tt.minor || v.Patch != tt.patch {
+			t.Errorf("ParseServerVersion(%q): expected %d.%d.%d, got %d.%d.%d", tt.in, tt.major, tt.minor, tt.patch, v.Major, v.Minor, v.Patch)
+		}
+	}
+
+	if _, err := ParseServerVersion("devel"); err == nil {
+		t.Error("Expected error for non-numeric version")
+	}
+}
+
+func TestDetectVersion(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/controller/v2/config/":
+			w.Write([]byte(`{"version": "4.6.2", "license_info": {"license_type": "enterprise"}}`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "admin", "password", "", false)
+	if err := c.DetectVersion(context.Background()); err != nil {
+		t.Fatalf("DetectVersion failed: %s", err)
+	}
+	if c.Version == nil || c.Version.Raw != "4.6.2" || c.Version.AWX {
+		t.Errorf("Expected controller 4.6.2, got %+v", c.Version)
+	}
+	if err := c.Supports(FeatureInventoryScripts); err == nil {
+		t.Error("Expected inventory scripts to be unsupported on 4.6.2")
+	}
+}
+
+func TestDetectVersionFallsBackToPing(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/config/":
+			w.WriteHeader(http.StatusForbidden)
+		case "/api/v2/ping/":
+			w.Write([]byte(`{"version": "17.1.0", "ha": false}`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "admin", "password", "", false)
+	c.APIPath = "/api/v2/"
+	if err := c.DetectVersion(context.Background()); err != nil {
+		t.Fatalf("DetectVersion failed: %s", err)
+	}
+	if c.Version == nil || c.Version.Major != 17 || !c.Version.AWX {
+		t.Errorf("Expected AWX 17, got %+v", c.Version)
+	}
+	if err := c.Supports(FeatureInventoryScripts); err != nil {
+		t.Errorf("Expected inventory scripts to be supported on AWX 17.1.0, got %s", err)
+	}
+}
+
+func TestDetectVersionFromPingLeavesOldProductsUnknown(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/config/":
+			w.WriteHeader(http.StatusForbidden)
+		case "/api/v2/ping/":
+			// Tower 3.8 and AWX 3.0 both report versions like this.
+			w.Write([]byte(`{"version": "3.8.6", "ha": false}`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "admin", "password", "", false)
+	c.APIPath = "/api/v2/"
+	if err := c.DetectVersion(context.Background()); err != nil {
+		t.Fatalf("DetectVersion failed: %s", err)
+	}
+	if c.Version == nil || !c.Version.ProductUnknown {
+		t.Errorf("Expected unknown product, got %+v", c.Version)
+	}
+	if err := c.Supports(Feature{Name: "Widgets", Controller: VersionRange{Since: "4.0.0"}}); err != nil {
+		t.Errorf("Expected features not to be gated for an unknown product, got %s", err)
+	}
+}
+
+func TestSupports(t *testing.T) {
+	feature := Feature{
+		Name:       "Widgets",
+		Controller: VersionRange{Since: "4.2.0", Until: "4.6.0"},
+		AWX:        VersionRange{Since: "21.0.0"},
+	}
+	tests := []struct {
+		version string
+		awx     bool
+		ok      bool
+	}{
+		{"4.1.9", false, false},
+		{"4.2.0", false, true},
+		{"4.5.3", false, true},
+		{"4.6.0", false, false},
+		{"20.1.0", true, false},
+		{"24.6.1", true, true},
+	}
+	for _, tt := range tests {
+		v, _ := ParseServerVersion(tt.version)
+		v.AWX = tt.awx
+		c := &Client{Version: &v}
+		if err := c.Supports(feature); (err == nil) != tt.ok {
+			t.Errorf("Supports on %s: expected ok=%t, got %v", v, tt.ok, err)
+		}
+	}
+
+	if err := (&Client{}).Supports(feature); err != nil {
+		t.Errorf("Expected unknown version to be supported, got %s", err)
+	}
+}
@@ -0,0 +1,381 @@
+package fakeaap
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type fieldKind int
+
+const (
+	kindString fieldKind = iota
+	kindInt
+	kindBool
+	kindObject
+	kindRef
+)
+
+// field describes one writable attribute of a collection.
+type field struct {
+	kind     fieldKind
+	required bool
+	nullable bool
+	def      interface{}
+	// ref is the collection a kindRef field points to.
+	ref string
+	// choices restricts the values of a kindString field.
+	choices []string
+}
+
+// collection describes one controller object type.
+type collection struct {
+	// name is the URL segment, e.g. "inventories".
+	name string
+	// typ is the value of the read-only "type" field, e.g. "inventory".
+	typ string
+	// label names the type in error messages, e.g. "Inventory".
+	label  string
+	fields map[string]field
+	// unique lists fields that together with name must be unique.
+	unique []string
+	// validate performs cross-field checks once the fields are valid. old is
+	// nil on create.
+	validate func(s *Server, obj, old map[string]interface{}) validationError
+	// derive fills in fields the controller computes when the object with
+	// the given ID is saved.
+	derive func(id int, obj map[string]interface{})
+	// until is the first major controller version without the collection.
+	until int
+}
+
+func str(def string) field { return field{kind: kindString, def: def} }
+
+func optionalRef(ref string) field { return field{kind: kindRef, ref: ref, nullable: true} }
+
+var required = field{kind: kindString, required: true}
+
+var collections = map[string]*collection{
+	"organizations": {
+		name:  "organizations",
+		typ:   "organization",
+		label: "Organization",
+		fields: map[string]field{
+			"name":              required,
+			"description":       str(""),
+			"max_hosts":         {kind: kindInt, def: 0},
+			"custom_virtualenv": {kind: kindString, nullable: true},
+		},
+	},
+	"inventories": {
+		name:  "inventories",
+		typ:   "inventory",
+		label: "Inventory",
+		fields: map[string]field{
+			"name":         required,
+			"description":  str(""),
+			"organization": {kind: kindRef, ref: "organizations", required: true},
+			"kind":         {kind: kindString, def: "", choices: []string{"", "smart", "constructed"}},
+			"host_filter":  {kind: kindString, nullable: true},
+			"variables":    str(""),
+		},
+		unique: []string{"organization"},
+		validate: func(s *Server, obj, old map[string]interface{}) validationError {
+			if obj["kind"] == "smart" && (obj["host_filter"] == nil || obj["host_filter"] == "") {
+				return validationError{"host_filter": []string{"Smart inventories must specify host_filter"}}
+			}
+			return nil
+		},
+	},
+	"projects": {
+		name:  "projects",
+		typ:   "project",
+		label: "Project",
+		fields: map[string]field{
+			"name":                     required,
+			"description":              str(""),
+			"organization":             optionalRef("organizations"),
+			"scm_type":                 {kind: kindString, def: "", choices: []string{"", "git", "svn", "insights", "archive"}},
+			"scm_url":                  str(""),
+			"scm_branch":               str(""),
+			"credential":               optionalRef("credentials"),
+			"scm_clean":                {kind: kindBool, def: false},
+			"scm_delete_on_update":     {kind: kindBool, def: false},
+			"scm_update_on_launch":     {kind: kindBool, def: false},
+			"scm_update_cache_timeout": {kind: kindInt, def: 0},
+			"local_path":               str(""),
+		},
+		unique: []string{"organization"},
+		validate: func(s *Server, obj, old map[string]interface{}) validationError {
+			if obj["scm_type"] != "" && obj["scm_url"] == "" {
+				return validationError{"scm_url": []string{"SCM URL is required."}}
+			}
+			return nil
+		},
+		// SCM projects are checked out under a generated path unless one
+		// generated earlier is kept.
+		derive: func(id int, obj map[string]interface{}) {
+			if obj["scm_type"] != "" && !strings.HasPrefix(obj["local_path"].(string), "_") {
+				obj["local_path"] = fmt.Sprintf("_%d__%s", id, slugify(obj["name"].(string)))
+			}
+		},
+	},
+	"credential_types": {
+		name:  "credential_types",
+		typ:   "credential_type",
+		label: "Credential type",
+		fields: map[string]field{
+			"name":        required,
+			"description": str(""),
+			"kind":        {kind: kindString, required: true, choices: []string{"ssh", "vault", "net", "scm", "cloud", "registry", "token", "insights", "external", "kubernetes", "galaxy", "cryptography"}},
+			"inputs":      {kind: kindObject, def: map[string]interface{}{}},
+			"injectors":   {kind: kindObject, def: map[string]interface{}{}},
+		},
+		unique:   []string{"kind"},
+		validate: validateCredentialType,
+	},
+	"credentials": {
+		name:  "credentials",
+		typ:   "credential",
+		label: "Credential",
+		fields: map[string]field{
+			"name":            required,
+			"description":     str(""),
+			"organization":    optionalRef("organizations"),
+			"credential_type": {kind: kindRef, ref: "credential_types", required: true},
+			"inputs":          {kind: kindObject, def: map[string]interface{}{}},
+			// user and team assign an owner on create and are not stored.
+			"user": {kind: kindInt, nullable: true},
+			"team": {kind: kindInt, nullable: true},
+		},
+		unique:   []string{"organization", "credential_type"},
+		validate: validateCredential,
+	},
+	"inventory_sources": {
+		name:  "inventory_sources",
+		typ:   "inventory_source",
+		label: "Inventory source",
+		fields: map[string]field{
+			"name":                 required,
+			"description":          str(""),
+			"inventory":            {kind: kindRef, ref: "inventories", required: true},
+			"source":               {kind: kindString, required: true, choices: []string{"file", "scm", "ec2", "gce", "azure_rm", "vmware", "satellite6", "openstack", "rhv", "controller", "insights", "constructed", "custom"}},
+			"source_path":          str(""),
+			"source_vars":          str(""),
+			"credential":           optionalRef("credentials"),
+			"source_project":       optionalRef("projects"),
+			"update_on_launch":     {kind: kindBool, def: false},
+			"update_cache_timeout": {kind: kindInt, def: 0},
+			"overwrite":            {kind: kindBool, def: false},
+			"overwrite_vars":       {kind: kindBool, def: false},
+		},
+		unique: []string{"inventory"},
+		validate: func(s *Server, obj, old map[string]interface{}) validationError {
+			if obj["source"] == "scm" && obj["source_project"] == nil {
+				return validationError{"source_project": []string{"Project required for scm type sources."}}
+			}
+			return nil
+		},
+	},
+	"inventory_scripts": {
+		name:  "inventory_scripts",
+		typ:   "custom_inventory_script",
+		label: "Custom inventory script",
+		fields: map[string]field{
+			"name":         required,
+			"description":  str(""),
+			"organization": {kind: kindRef, ref: "organizations", required: true},
+			"script":       required,
+		},
+		unique: []string{"organization"},
+		until:  4,
+	},
+	"job_templates": {
+		name:  "job_templates",
+		typ:   "job_template",
+		label: "Job template",
+		fields: map[string]field{
+			"name":        required,
+			"description": str(""),
+			"job_type":    {kind: kindString, def: "run", choices: []string{"run", "check"}},
+			"inventory":   optionalRef("inventories"),
+			"project":     {kind: kindRef, ref: "projects", required: true},
+			"playbook":    required,
+			"forks":       {kind: kindInt, def: 0},
+			"limit":       str(""),
+			"verbosity":   {kind: kindInt, def: 0},
+			"extra_vars":  str(""),
+		},
+		validate: func(s *Server, obj, old map[string]interface{}) validationError {
+			if v, _ := toInt(obj["verbosity"]); v < 0 || v > 5 {
+				return validationError{"verbosity": []string{fmt.Sprintf("\"%d\" is not a valid choice.", v)}}
+			}
+			return nil
+		},
+	},
+}
+
+// managedCredentialTypes are the built-in types present on every controller,
+// with the IDs they have on a fresh install.
+var managedCredentialTypes = []map[string]interface{}{
+	{
+		"id":          1,
+		"name":        "Machine",
+		"description": "",
+		"kind":        "ssh",
+		"namespace":   "ssh",
+		"managed":     true,
+		"inputs": map[string]interface{}{
+			"fields": []interface{}{
+				map[string]interface{}{"id": "username", "label": "Username", "type": "string"},
+				map[string]interface{}{"id": "password", "label": "Password", "type": "string", "secret": true, "ask_at_runtime": true},
+				map[string]interface{}{"id": "ssh_key_data", "label": "SSH Private Key", "type": "string", "format": "ssh_private_key", "secret": true, "multiline": true},
+				map[string]interface{}{"id": "ssh_public_key_data", "label": "Signed SSH Certificate", "type": "string", "multiline": true, "secret": true},
+				map[string]interface{}{"id": "ssh_key_unlock", "label": "Private Key Passphrase", "type": "string", "secret": true, "ask_at_runtime": true},
+				map[string]interface{}{"id": "become_method", "label": "Privilege Escalation Method", "type": "string"},
+				map[string]interface{}{"id": "become_username", "label": "Privilege Escalation Username", "type": "string"},
+				map[string]interface{}{"id": "become_password", "label": "Privilege Escalation Password", "type": "string", "secret": true, "ask_at_runtime": true},
+			},
+		},
+		"injectors": map[string]interface{}{},
+	},
+	{
+		"id":          2,
+		"name":        "Source Control",
+		"description": "",
+		"kind":        "scm",
+		"namespace":   "scm",
+		"managed":     true,
+		"inputs": map[string]interface{}{
+			"fields": []interface{}{
+				map[string]interface{}{"id": "username", "label": "Username", "type": "string"},
+				map[string]interface{}{"id": "password", "label": "Password", "type": "string", "secret": true},
+				map[string]interface{}{"id": "ssh_key_data", "label": "SCM Private Key", "type": "string", "format": "ssh_private_key", "secret": true, "multiline": true},
+				map[string]interface{}{"id": "ssh_key_unlock", "label": "Private Key Passphrase", "type": "string", "secret": true},
+			},
+		},
+		"injectors": map[string]interface{}{},
+	},
+}
+
+// validateCredentialType checks the shape of a custom type's input schema.
+func validateCredentialType(s *Server, obj, old map[string]interface{}) validationError {
+	if old != nil && old["managed"] == true {
+		return nil
+	}
+	if kind := obj["kind"]; kind != "cloud" && kind != "net" {
+		return validationError{"kind": []string{"Must be 'cloud' or 'net', not " + fmt.Sprint(kind)}}
+	}
+	inputs := obj["inputs"].(map[string]interface{})
+	fields, ok := inputs["fields"]
+	if !ok {
+		return nil
+	}
+	list, ok := fields.([]interface{})
+	if !ok {
+		return validationError{"inputs": map[string]interface{}{"fields": []string{"must be an array"}}}
+	}
+	for i, f := range list {
+		m, ok := f.(map[string]interface{})
+		if !ok {
+			return validationError{"inputs": map[string]interface{}{"fields": []string{fmt.Sprintf("item %d must be an object", i)}}}
+		}
+		for _, key := range []string{"id", "label"} {
+			if _, ok := m[key].(string); !ok {
+				return validationError{"inputs": map[string]interface{}{"fields": []string{fmt.Sprintf("'%s' is a required property of item %d", key, i)}}}
+			}
+		}
+	}
+	return nil
+}
+
+// validateCredential requires an owner on create and checks inputs against
+// the credential type's input schema. Secret inputs sent as $encrypted$ keep
+// their stored value.
+func validateCredential(s *Server, obj, old map[string]interface{}) validationError {
+	if old == nil && obj["organization"] == nil && obj["user"] == nil && obj["team"] == nil {
+		return validationError{"detail": "Missing 'user', 'team', or 'organization'."}
+	}
+	delete(obj, "user")
+	delete(obj, "team")
+
+	id, _ := toInt(obj["credential_type"])
+	ct := s.objects["credential_types"][id]
+	inputs := obj["inputs"].(map[string]interface{})
+	schema := inputFields(ct)
+
+	errs := map[string]interface{}{}
+	for key, v := range inputs {
+		def, ok := schema[key]
+		if !ok {
+			errs[key] = []string{fmt.Sprintf("Additional properties are not allowed ('%s' was unexpected)", key)}
+			continue
+		}
+		if v == encrypted && def["secret"] == true {
+			if old != nil {
+				if prior, ok := old["inputs"].(map[string]interface{})[key]; ok {
+					inputs[key] = prior
+					continue
+				}
+			}
+			delete(inputs, key)
+			continue
+		}
+		switch def["type"] {
+		case "boolean":
+			if _, ok := v.(bool); !ok {
+				errs[key] = []string{fmt.Sprintf("%v is not of type 'boolean'", v)}
+			}
+		default:
+			if _, ok := v.(string); !ok {
+				errs[key] = []string{fmt.Sprintf("%v is not of type 'string'", v)}
+			}
+		}
+	}
+	if req, ok := ct["inputs"].(map[string]interface{})["required"].([]interface{}); ok {
+		for _, r := range req {
+			key := fmt.Sprint(r)
+			if v, ok := inputs[key]; !ok || v == "" {
+				errs[key] = []string{"required for " + fmt.Sprint(ct["name"])}
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return validationError{"inputs": errs}
+	}
+	return nil
+}
+
+// inputFields returns a credential type's input fields keyed by ID.
+func inputFields(ct map[string]interface{}) map[string]map[string]interface{} {
+	out := map[string]map[string]interface{}{}
+	inputs, _ := ct["inputs"].(map[string]interface{})
+	fields, _ := inputs["fields"].([]interface{})
+	for _, f := range fields {
+		if m, ok := f.(map[string]interface{}); ok {
+			out[fmt.Sprint(m["id"])] = m
+		}
+	}
+	return out
+}
+
+// slugify converts name the way the controller does for project paths:
+// lower case, with punctuation dropped and runs of spaces and hyphens
+// replaced by an underscore.
+func slugify(name string) string {
+	var b strings.Builder
+	sep := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			if sep && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			sep = false
+			b.WriteRune(r)
+		case unicode.IsSpace(r) || r == '-':
+			sep = true
+		}
+	}
+	return strings.Trim(b.String(), "_")
+}
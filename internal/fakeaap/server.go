@@ -0,0 +1,291 @@
+// Package fakeaap is an in-memory stand-in for the AAP controller API, used
+// to run the provider's acceptance tests without a real controller.
+//
+// It serves organizations, inventories, projects, credentials, credential
+// types, inventory sources, inventory scripts and job templates with the
+// controller's defaults, validation errors, filtering and pagination, behind
+// Basic or Bearer authentication.
+package fakeaap
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default credentials accepted by a new Server.
+const (
+	DefaultUsername = "admin"
+	DefaultPassword = "password"
+)
+
+// encrypted is returned in place of secret credential inputs.
+const encrypted = "$encrypted$"
+
+// Server is a fake AAP API. Configure the exported fields before sending the
+// first request.
+type Server struct {
+	*httptest.Server
+
+	// Username and Password are accepted for Basic auth and token requests.
+	Username string
+	Password string
+	// Version is reported by the config/ and ping/ endpoints. Inventory
+	// scripts are only served for versions before 4.0.
+	Version string
+	// APIPath is the controller API prefix. Prefixes under /api/controller/
+	// are served behind a fake Platform Gateway; others, such as /api/v2/,
+	// are served like AWX or AAP 2.4.
+	APIPath string
+	// PageSize is the default page size for list requests.
+	PageSize int
+
+	mu      sync.Mutex
+	objects map[string]map[int]map[string]interface{}
+	nextID  map[string]int
+	tokens  map[string]int
+	updates map[string]map[int][]map[string]interface{}
+}
+
+// New starts a Server with the managed Machine and Source Control credential
+// types already defined. Callers must Close it.
+func New() *Server {
+	s := &Server{
+		Username: DefaultUsername,
+		Password: DefaultPassword,
+		Version:  "4.6.2",
+		APIPath:  "/api/controller/v2/",
+		PageSize: 25,
+		objects:  map[string]map[int]map[string]interface{}{},
+		nextID:   map[string]int{},
+		tokens:   map[string]int{},
+		updates:  map[string]map[int][]map[string]interface{}{},
+	}
+	for name := range collections {
+		s.objects[name] = map[int]map[string]interface{}{}
+		s.updates[name] = map[int][]map[string]interface{}{}
+		s.nextID[name] = 1
+	}
+	now := timestamp()
+	for _, ct := range managedCredentialTypes {
+		obj := normalize(ct).(map[string]interface{})
+		id := obj["id"].(int)
+		delete(obj, "id")
+		obj["created"], obj["modified"] = now, now
+		s.objects["credential_types"][id] = obj
+		s.nextID["credential_types"] = id + 1
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// validationError is the body of a 400 response, keyed by field name.
+type validationError map[string]interface{}
+
+func (e validationError) Error() string {
+	b, _ := json.Marshal(e)
+	return string(b)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := r.URL.Path
+	gateway := strings.HasPrefix(s.APIPath, "/api/controller/")
+
+	switch {
+	case p == "/api/" && r.Method == http.MethodGet:
+		if gateway {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"apis": map[string]string{"gateway": "/api/gateway/", "controller": "/api/controller/"}})
+		} else {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"description": "AWX REST API", "current_version": s.APIPath})
+		}
+		return
+	case gateway && p == "/api/controller/" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"current_version": s.APIPath})
+		return
+	case p == s.APIPath+"ping/" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"version": s.Version, "ha": false})
+		return
+	}
+
+	if !s.authenticated(r) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"detail": "Authentication credentials were not provided."})
+		return
+	}
+
+	tokensPath := s.APIPath + "tokens/"
+	if gateway {
+		tokensPath = "/api/gateway/v1/tokens/"
+	}
+	if strings.HasPrefix(p, tokensPath) {
+		s.serveTokens(w, r, strings.TrimPrefix(p, tokensPath))
+		return
+	}
+
+	rest, ok := strings.CutPrefix(p, s.APIPath)
+	if !ok {
+		notFound(w)
+		return
+	}
+	if rest == "config/" && r.Method == http.MethodGet {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"version": s.Version, "license_info": map[string]string{"license_type": "enterprise"}})
+		return
+	}
+
+	name, idPart, _ := strings.Cut(strings.TrimSuffix(rest, "/"), "/")
+	c, ok := collections[name]
+	if !ok || (c.until > 0 && s.major() >= c.until) {
+		notFound(w)
+		return
+	}
+	if idPart == "" {
+		switch r.Method {
+		case http.MethodGet:
+			s.serveList(w, r, c)
+		case http.MethodPost:
+			s.serveCreate(w, r, c)
+		default:
+			methodNotAllowed(w, r)
+		}
+		return
+	}
+
+	id, err := strconv.Atoi(idPart)
+	if err != nil || strings.Contains(idPart, "/") {
+		notFound(w)
+		return
+	}
+	if _, ok := s.objects[c.name][id]; !ok {
+		notFound(w)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.render(c, id))
+	case http.MethodPatch, http.MethodPut:
+		s.serveUpdate(w, r, c, id, r.Method == http.MethodPatch)
+	case http.MethodDelete:
+		if err := s.delete(c, id); err != nil {
+			writeJSON(w, http.StatusForbidden, map[string]string{"detail": err.Error()})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) authenticated(r *http.Request) bool {
+	if user, pass, ok := r.BasicAuth(); ok {
+		return user == s.Username && pass == s.Password
+	}
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		_, ok := s.tokens[token]
+		return ok
+	}
+	return false
+}
+
+// serveTokens issues and revokes personal access tokens.
+func (s *Server) serveTokens(w http.ResponseWriter, r *http.Request, rest string) {
+	switch {
+	case rest == "" && r.Method == http.MethodPost:
+		b := make([]byte, 16)
+		rand.Read(b)
+		token := hex.EncodeToString(b)
+		id := s.nextID["tokens"] + 1
+		s.nextID["tokens"] = id
+		s.tokens[token] = id
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"id": id, "token": token, "scope": "write"})
+	case rest != "" && r.Method == http.MethodDelete:
+		id, _ := strconv.Atoi(strings.TrimSuffix(rest, "/"))
+		for token, tid := range s.tokens {
+			if tid == id {
+				delete(s.tokens, token)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		notFound(w)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) serveCreate(w http.ResponseWriter, r *http.Request, c *collection) {
+	body, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	id, err := s.create(c, body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, s.render(c, id))
+}
+
+func (s *Server) serveUpdate(w http.ResponseWriter, r *http.Request, c *collection, id int, partial bool) {
+	if s.objects[c.name][id]["managed"] == true {
+		writeJSON(w, http.StatusForbidden, map[string]string{"detail": "Modifications not allowed for managed credential types"})
+		return
+	}
+	body, ok := decodeBody(w, r)
+	if !ok {
+		return
+	}
+	sent := normalize(body).(map[string]interface{})
+	if err := s.update(c, id, body, partial); err != nil {
+		writeJSON(w, http.StatusBadRequest, err)
+		return
+	}
+	s.updates[c.name][id] = append(s.updates[c.name][id], sent)
+	writeJSON(w, http.StatusOK, s.render(c, id))
+}
+
+// major returns the major component of Version.
+func (s *Server) major() int {
+	major, _, _ := strings.Cut(s.Version, ".")
+	n, _ := strconv.Atoi(major)
+	return n
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request) (map[string]interface{}, bool) {
+	dec := json.NewDecoder(r.Body)
+	dec.UseNumber()
+	var body map[string]interface{}
+	if err := dec.Decode(&body); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"detail": "JSON parse error - " + err.Error()})
+		return nil, false
+	}
+	return normalize(body).(map[string]interface{}), true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-API-Request-Id", fmt.Sprintf("%x", time.Now().UnixNano()))
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func notFound(w http.ResponseWriter) {
+	writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+}
+
+func methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"detail": fmt.Sprintf("Method \"%s\" not allowed.", r.Method)})
+}
+
+func timestamp() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05.000000Z")
+}
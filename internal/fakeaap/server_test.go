@@ -0,0 +1,239 @@
+package fakeaap_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dhikrahashim/terraform-provider-aap/internal/client"
+	"github.com/dhikrahashim/terraform-provider-aap/internal/fakeaap"
+)
+
+func newClient(t *testing.T) (*fakeaap.Server, *client.Client) {
+	t.Helper()
+	s := fakeaap.New()
+	t.Cleanup(s.Close)
+	c := client.NewClient(s.URL, fakeaap.DefaultUsername, fakeaap.DefaultPassword, "", false)
+	c.Retry.MaxRetries = 0
+	return s, c
+}
+
+func TestCRUD(t *testing.T) {
+	_, c := newClient(t)
+	ctx := context.Background()
+
+	org, err := c.Organizations.Create(ctx, &client.Organization{Name: "Test Org", MaxHosts: 10})
+	if err != nil {
+		t.Fatalf("Create failed: %s", err)
+	}
+	if org.ID == 0 || org.Name != "Test Org" || org.MaxHosts != 10 {
+		t.Errorf("Unexpected organization %+v", org)
+	}
+
+	org, err = c.Organizations.Patch(ctx, org.ID, map[string]interface{}{"description": "updated"})
+	if err != nil {
+		t.Fatalf("Patch failed: %s", err)
+	}
+	if org.Description != "updated" || org.MaxHosts != 10 {
+		t.Errorf("Expected only description to change, got %+v", org)
+	}
+
+	if err := c.Organizations.Delete(ctx, org.ID); err != nil {
+		t.Fatalf("Delete failed: %s", err)
+	}
+	if _, err := c.Organizations.Get(ctx, org.ID); !client.IsNotFound(err) {
+		t.Errorf("Expected not found after delete, got %v", err)
+	}
+}
+
+func TestUnauthenticated(t *testing.T) {
+	s, _ := newClient(t)
+	c := client.NewClient(s.URL, "admin", "wrong", "", false)
+	var apiErr *client.APIError
+	if _, err := c.Organizations.Get(context.Background(), 1); !errors.As(err, &apiErr) || apiErr.StatusCode != 401 {
+		t.Errorf("Expected 401, got %v", err)
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	_, c := newClient(t)
+	ctx := context.Background()
+
+	var apiErr *client.APIError
+	_, err := c.Inventories.Create(ctx, &client.Inventory{Name: "Inv", Organization: 99})
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 400 {
+		t.Fatalf("Expected 400, got %v", err)
+	}
+	if len(apiErr.Fields["organization"]) != 1 {
+		t.Errorf("Expected organization field error, got %+v", apiErr.Fields)
+	}
+
+	org, _ := c.Organizations.Create(ctx, &client.Organization{Name: "Org"})
+	if _, err := c.Inventories.Create(ctx, &client.Inventory{Name: "Inv", Organization: org.ID}); err != nil {
+		t.Fatalf("Create failed: %s", err)
+	}
+	_, err = c.Inventories.Create(ctx, &client.Inventory{Name: "Inv", Organization: org.ID})
+	if !errors.As(err, &apiErr) || len(apiErr.NonFieldErrors) != 1 {
+		t.Errorf("Expected duplicate name error, got %v", err)
+	}
+
+	_, err = c.Projects.Create(ctx, &client.Project{Name: "Proj", Organization: org.ID, ScmType: "git"})
+	if !errors.As(err, &apiErr) || len(apiErr.Fields["scm_url"]) != 1 {
+		t.Errorf("Expected scm_url error, got %v", err)
+	}
+}
+
+func TestPagination(t *testing.T) {
+	s, c := newClient(t)
+	s.PageSize = 2
+	ctx := context.Background()
+
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		if _, err := s.Create("organizations", map[string]interface{}{"name": name}); err != nil {
+			t.Fatalf("Create failed: %s", err)
+		}
+	}
+
+	var names []string
+	for org, err := range c.Organizations.List(ctx, client.ListOptions{OrderBy: "-name"}) {
+		if err != nil {
+			t.Fatalf("List failed: %s", err)
+		}
+		names = append(names, org.Name)
+	}
+	if len(names) != 5 || names[0] != "e" || names[4] != "a" {
+		t.Errorf("Expected 5 organizations in descending order, got %v", names)
+	}
+
+	var found []string
+	for org, err := range c.Organizations.List(ctx, client.ListOptions{Name: "c"}) {
+		if err != nil {
+			t.Fatalf("List failed: %s", err)
+		}
+		found = append(found, org.Name)
+	}
+	if len(found) != 1 {
+		t.Errorf("Expected 1 match for name filter, got %v", found)
+	}
+}
+
+func TestCredentialSecrets(t *testing.T) {
+	s, c := newClient(t)
+	ctx := context.Background()
+
+	org, _ := c.Organizations.Create(ctx, &client.Organization{Name: "Org"})
+	cred, err := c.Credentials.Create(ctx, &client.Credential{
+		Name:           "Machine",
+		Organization:   org.ID,
+		CredentialType: 1,
+		Inputs:         client.CredentialInputs{"username": "deploy", "password": "hunter2"},
+	})
+	if err != nil {
+		t.Fatalf("Create failed: %s", err)
+	}
+	if cred.Inputs["username"] != "deploy" || cred.Inputs["password"] != "$encrypted$" {
+		t.Errorf("Expected masked password, got %+v", cred.Inputs)
+	}
+
+	// Sending the mask back keeps the stored secret.
+	_, err = c.Credentials.Patch(ctx, cred.ID, map[string]interface{}{
+		"inputs": map[string]interface{}{"username": "ops", "password": "$encrypted$"},
+	})
+	if err != nil {
+		t.Fatalf("Patch failed: %s", err)
+	}
+	inputs := s.Object("credentials", cred.ID)["inputs"].(map[string]interface{})
+	if inputs["username"] != "ops" || inputs["password"] != "hunter2" {
+		t.Errorf("Expected password to be kept, got %v", inputs)
+	}
+	if updates := s.Updates("credentials", cred.ID); len(updates) != 1 || updates[0]["inputs"] == nil {
+		t.Errorf("Expected one update with inputs, got %v", updates)
+	}
+
+	var apiErr *client.APIError
+	_, err = c.Credentials.Patch(ctx, cred.ID, map[string]interface{}{"inputs": map[string]interface{}{"bogus": "x"}})
+	if !errors.As(err, &apiErr) || len(apiErr.Fields["inputs.bogus"]) != 1 {
+		t.Errorf("Expected inputs.bogus error, got %v", err)
+	}
+}
+
+func TestCredentialRequiresOwner(t *testing.T) {
+	_, c := newClient(t)
+	ctx := context.Background()
+
+	var apiErr *client.APIError
+	_, err := c.Credentials.Create(ctx, &client.Credential{Name: "Machine", CredentialType: 1})
+	if !errors.As(err, &apiErr) || apiErr.Detail != "Missing 'user', 'team', or 'organization'." {
+		t.Errorf("Expected missing owner error, got %v", err)
+	}
+}
+
+func TestDeleteCascades(t *testing.T) {
+	s, _ := newClient(t)
+
+	org, _ := s.Create("organizations", map[string]interface{}{"name": "Org"})
+	inv, _ := s.Create("inventories", map[string]interface{}{"name": "Inv", "organization": org})
+	proj, _ := s.Create("projects", map[string]interface{}{"name": "Proj", "organization": org})
+	if err := s.Delete("organizations", org); err != nil {
+		t.Fatalf("Delete failed: %s", err)
+	}
+	if s.Object("inventories", inv) != nil {
+		t.Error("Expected inventory to be deleted with its organization")
+	}
+	if p := s.Object("projects", proj); p == nil || p["organization"] != nil {
+		t.Errorf("Expected project to remain without organization, got %v", p)
+	}
+}
+
+func TestInventoryScriptsByVersion(t *testing.T) {
+	s, c := newClient(t)
+	ctx := context.Background()
+	org, _ := c.Organizations.Create(ctx, &client.Organization{Name: "Org"})
+	script := &client.InventoryScript{Name: "Script", Organization: org.ID, Script: "#!/bin/sh\necho {}"}
+
+	if _, err := c.InventoryScripts.Create(ctx, script); !client.IsNotFound(err) {
+		t.Errorf("Expected not found on 4.x, got %v", err)
+	}
+
+	s.Version = "3.8.6"
+	if _, err := c.InventoryScripts.Create(ctx, script); err != nil {
+		t.Errorf("Create failed on 3.8: %s", err)
+	}
+}
+
+func TestTokensAndDiscovery(t *testing.T) {
+	s, c := newClient(t)
+	ctx := context.Background()
+
+	if err := c.DiscoverAPIPath(ctx); err != nil || c.APIPath != "/api/controller/v2/" {
+		t.Fatalf("Expected gateway API path, got %s (%v)", c.APIPath, err)
+	}
+	if err := c.DetectVersion(ctx); err != nil || c.Version.Raw != s.Version {
+		t.Fatalf("Expected version %s, got %v (%v)", s.Version, c.Version, err)
+	}
+	tok, err := c.AcquireOAuth2Token(ctx, "test")
+	if err != nil {
+		t.Fatalf("AcquireOAuth2Token failed: %s", err)
+	}
+	if _, err := c.Organizations.Create(ctx, &client.Organization{Name: "Org"}); err != nil {
+		t.Errorf("Create with token failed: %s", err)
+	}
+	if err := c.RevokeOAuth2Token(ctx, tok); err != nil {
+		t.Errorf("RevokeOAuth2Token failed: %s", err)
+	}
+	if _, err := c.Organizations.Get(ctx, 1); err == nil {
+		t.Error("Expected revoked token to be rejected")
+	}
+}
+
+func TestLegacyAPIPath(t *testing.T) {
+	s, c := newClient(t)
+	s.APIPath = "/api/v2/"
+
+	if err := c.DiscoverAPIPath(context.Background()); err != nil || c.APIPath != "/api/v2/" {
+		t.Fatalf("Expected legacy API path, got %s (%v)", c.APIPath, err)
+	}
+	if _, err := c.Organizations.Create(context.Background(), &client.Organization{Name: "Org"}); err != nil {
+		t.Errorf("Create failed: %s", err)
+	}
+}
@@ -0,0 +1,474 @@
+package fakeaap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// create validates body against c and stores it, returning the new ID.
+func (s *Server) create(c *collection, body map[string]interface{}) (int, error) {
+	id := s.nextID[c.name]
+	obj := map[string]interface{}{}
+	if err := s.apply(c, id, obj, nil, body, false); err != nil {
+		return 0, err
+	}
+	s.nextID[c.name]++
+	now := timestamp()
+	obj["created"], obj["modified"] = now, now
+	s.objects[c.name][id] = obj
+	return id, nil
+}
+
+// update validates body against c and applies it to the object. Partial
+// updates leave omitted fields alone; full updates reset them to defaults.
+func (s *Server) update(c *collection, id int, body map[string]interface{}, partial bool) error {
+	old := s.objects[c.name][id]
+	obj := make(map[string]interface{}, len(old))
+	for k, v := range old {
+		obj[k] = v
+	}
+	if err := s.apply(c, id, obj, old, body, partial); err != nil {
+		return err
+	}
+	obj["modified"] = timestamp()
+	s.objects[c.name][id] = obj
+	return nil
+}
+
+// apply copies the fields of c present in body into obj, filling defaults for
+// absent fields unless partial, then runs the collection's checks. Fields
+// that are not writable are ignored, as by the controller.
+func (s *Server) apply(c *collection, id int, obj, old, body map[string]interface{}, partial bool) error {
+	errs := validationError{}
+	for name, f := range c.fields {
+		v, ok := body[name]
+		if !ok {
+			switch {
+			case partial:
+			case f.required:
+				errs[name] = []string{"This field is required."}
+			default:
+				obj[name] = copyValue(f.def)
+			}
+			continue
+		}
+		v, msg := s.coerce(f, v)
+		if msg != "" {
+			errs[name] = []string{msg}
+			continue
+		}
+		obj[name] = v
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	if c.validate != nil {
+		if err := c.validate(s, obj, old); err != nil {
+			return err
+		}
+	}
+	if c.derive != nil {
+		c.derive(id, obj)
+	}
+	return s.checkUnique(c, id, obj)
+}
+
+// coerce checks v against f and returns the value to store, or an error
+// message worded like the controller's.
+func (s *Server) coerce(f field, v interface{}) (interface{}, string) {
+	if v == nil {
+		if f.nullable {
+			return nil, ""
+		}
+		return nil, "This field may not be null."
+	}
+	switch f.kind {
+	case kindString:
+		str, ok := v.(string)
+		if !ok {
+			return nil, "Not a valid string."
+		}
+		if str == "" && f.required {
+			return nil, "This field may not be blank."
+		}
+		if f.choices != nil && !slices.Contains(f.choices, str) {
+			return nil, fmt.Sprintf("\"%s\" is not a valid choice.", str)
+		}
+		return str, ""
+	case kindInt:
+		n, ok := toInt(v)
+		if !ok {
+			return nil, "A valid integer is required."
+		}
+		return n, ""
+	case kindBool:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, "Must be a valid boolean."
+		}
+		return b, ""
+	case kindObject:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, "Must be a valid JSON object."
+		}
+		return m, ""
+	case kindRef:
+		n, ok := toInt(v)
+		if !ok {
+			return nil, fmt.Sprintf("Incorrect type. Expected pk value, received %T.", v)
+		}
+		if _, ok := s.objects[f.ref][n]; !ok {
+			return nil, fmt.Sprintf("Invalid pk \"%d\" - object does not exist.", n)
+		}
+		return n, ""
+	}
+	return v, ""
+}
+
+// checkUnique rejects obj, stored under id, if another object shares its
+// name and unique fields.
+func (s *Server) checkUnique(c *collection, id int, obj map[string]interface{}) error {
+	for otherID, other := range s.objects[c.name] {
+		if otherID == id || other["name"] != obj["name"] {
+			continue
+		}
+		match := true
+		for _, f := range c.unique {
+			if other[f] != obj[f] {
+				match = false
+			}
+		}
+		if match {
+			fields := []string{"Name"}
+			for _, f := range c.unique {
+				fields = append(fields, strings.ToUpper(f[:1])+strings.ReplaceAll(f[1:], "_", " "))
+			}
+			return validationError{"__all__": []string{fmt.Sprintf("%s with this %s already exists.", c.label, strings.Join(fields, " and "))}}
+		}
+	}
+	return nil
+}
+
+// delete removes the object, cascading to objects that require it and
+// clearing optional references to it.
+func (s *Server) delete(c *collection, id int) error {
+	obj := s.objects[c.name][id]
+	if obj["managed"] == true {
+		return errors.New("Deletion not allowed for managed credential types")
+	}
+	if c.name == "credential_types" {
+		for _, cred := range s.objects["credentials"] {
+			if cred["credential_type"] == id {
+				return errors.New("Credential types that are in use cannot be deleted")
+			}
+		}
+	}
+
+	delete(s.objects[c.name], id)
+	for _, other := range collections {
+		for fname, f := range other.fields {
+			if f.kind != kindRef || f.ref != c.name {
+				continue
+			}
+			for oid, o := range s.objects[other.name] {
+				if o[fname] != id {
+					continue
+				}
+				if f.required {
+					s.delete(other, oid)
+				} else {
+					o[fname] = nil
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// render returns the object as the API serializes it, with read-only
+// fields, summaries of referenced objects, and secret inputs masked.
+func (s *Server) render(c *collection, id int) map[string]interface{} {
+	obj := s.objects[c.name][id]
+	out := map[string]interface{}{
+		"id":   id,
+		"type": c.typ,
+		"url":  fmt.Sprintf("%s%s/%d/", s.APIPath, c.name, id),
+	}
+	summary := map[string]interface{}{}
+	for k, v := range obj {
+		out[k] = copyValue(v)
+		if f, ok := c.fields[k]; ok && f.kind == kindRef && v != nil {
+			ref := s.objects[f.ref][v.(int)]
+			summary[k] = map[string]interface{}{"id": v, "name": ref["name"], "description": ref["description"]}
+		}
+	}
+	out["summary_fields"] = summary
+
+	if c.name == "credentials" {
+		ct := s.objects["credential_types"][obj["credential_type"].(int)]
+		fields := inputFields(ct)
+		inputs := out["inputs"].(map[string]interface{})
+		for k, v := range inputs {
+			if fields[k]["secret"] == true && v != "" {
+				inputs[k] = encrypted
+			}
+		}
+	}
+	return out
+}
+
+// serveList writes one page of objects matching the query's filters.
+func (s *Server) serveList(w http.ResponseWriter, r *http.Request, c *collection) {
+	q := r.URL.Query()
+	page, pageSize := 1, s.PageSize
+	if v := q.Get("page"); v != "" {
+		page, _ = strconv.Atoi(v)
+	}
+	if v := q.Get("page_size"); v != "" {
+		pageSize, _ = strconv.Atoi(v)
+	}
+	if page < 1 || pageSize < 1 {
+		notFound(w)
+		return
+	}
+	pageSize = min(pageSize, 200)
+
+	var ids []int
+	for id := range s.objects[c.name] {
+		ok, err := s.matches(c, id, q)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"detail": err.Error()})
+			return
+		}
+		if ok {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	if orderBy := q.Get("order_by"); orderBy != "" {
+		key, desc := strings.CutPrefix(orderBy, "-")
+		slices.SortStableFunc(ids, func(a, b int) int {
+			cmp := strings.Compare(fmt.Sprint(s.field(c, a, key)), fmt.Sprint(s.field(c, b, key)))
+			if x, ok := toInt(s.field(c, a, key)); ok {
+				y, _ := toInt(s.field(c, b, key))
+				cmp = x - y
+			}
+			if desc {
+				return -cmp
+			}
+			return cmp
+		})
+	}
+
+	results := []interface{}{}
+	start := (page - 1) * pageSize
+	if start > len(ids) || (start == len(ids) && page > 1) {
+		notFound(w)
+		return
+	}
+	for _, id := range ids[start:min(start+pageSize, len(ids))] {
+		results = append(results, s.render(c, id))
+	}
+
+	pageURL := func(n int) interface{} {
+		if n < 1 || (n-1)*pageSize >= len(ids) {
+			return nil
+		}
+		q := r.URL.Query()
+		q.Set("page", strconv.Itoa(n))
+		return r.URL.Path + "?" + q.Encode()
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"count":    len(ids),
+		"next":     pageURL(page + 1),
+		"previous": pageURL(page - 1),
+		"results":  results,
+	})
+}
+
+// matches applies the list filters in q to an object. Supported filters are
+// exact field matches, "<ref>__name" lookups, and search.
+func (s *Server) matches(c *collection, id int, q url.Values) (bool, error) {
+	for key, values := range q {
+		want := values[0]
+		switch key {
+		case "page", "page_size", "order_by":
+			continue
+		case "search":
+			text := strings.ToLower(fmt.Sprint(s.field(c, id, "name"), " ", s.field(c, id, "description")))
+			if !strings.Contains(text, strings.ToLower(want)) {
+				return false, nil
+			}
+			continue
+		}
+		if ref, ok := strings.CutSuffix(key, "__name"); ok {
+			f, ok := c.fields[ref]
+			if !ok || f.kind != kindRef {
+				return false, fmt.Errorf("Invalid field name: %s", key)
+			}
+			refID, ok := s.field(c, id, ref).(int)
+			if !ok || s.objects[f.ref][refID]["name"] != want {
+				return false, nil
+			}
+			continue
+		}
+		if _, ok := c.fields[key]; !ok && key != "id" && key != "managed" && key != "namespace" {
+			return false, fmt.Errorf("Invalid field name: %s", key)
+		}
+		v := s.field(c, id, key)
+		if v == nil || fmt.Sprint(v) != want {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (s *Server) field(c *collection, id int, key string) interface{} {
+	if key == "id" {
+		return id
+	}
+	return s.objects[c.name][id][key]
+}
+
+// normalize converts the numbers in a decoded JSON value, or in Go values
+// passed to the exported helpers, to int where they are integral.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			out[k] = normalize(val)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			out[i] = normalize(val)
+		}
+		return out
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return int(n)
+		}
+		f, _ := v.Float64()
+		return f
+	case float64:
+		if v == math.Trunc(v) {
+			return int(v)
+		}
+	}
+	return v
+}
+
+func toInt(v interface{}) (int, bool) {
+	switch v := v.(type) {
+	case int:
+		return v, true
+	case string:
+		n, err := strconv.Atoi(v)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func copyValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}, []interface{}:
+		return normalize(v)
+	}
+	return v
+}
+
+// Create stores an object as if it had been POSTed to the collection, such
+// as "organizations", and returns its ID.
+func (s *Server) Create(collection string, fields map[string]interface{}) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := collections[collection]
+	if !ok {
+		return 0, fmt.Errorf("unknown collection %q", collection)
+	}
+	return s.create(c, normalize(roundTrip(fields)).(map[string]interface{}))
+}
+
+// Update changes an object out of band, as if it had been PATCHed.
+func (s *Server) Update(collection string, id int, fields map[string]interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := collections[collection]
+	if !ok {
+		return fmt.Errorf("unknown collection %q", collection)
+	}
+	if _, ok := s.objects[collection][id]; !ok {
+		return fmt.Errorf("%s %d not found", collection, id)
+	}
+	return s.update(c, id, normalize(roundTrip(fields)).(map[string]interface{}), true)
+}
+
+// Delete removes an object out of band, cascading like the controller.
+func (s *Server) Delete(collection string, id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := collections[collection]
+	if !ok {
+		return fmt.Errorf("unknown collection %q", collection)
+	}
+	if _, ok := s.objects[collection][id]; !ok {
+		return fmt.Errorf("%s %d not found", collection, id)
+	}
+	return s.delete(c, id)
+}
+
+// Object returns the stored fields of an object, with secrets unmasked, or
+// nil if it does not exist.
+func (s *Server) Object(collection string, id int) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.objects[collection][id]
+	if !ok {
+		return nil
+	}
+	return normalize(obj).(map[string]interface{})
+}
+
+// Count returns the number of objects in a collection.
+func (s *Server) Count(collection string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.objects[collection])
+}
+
+// Updates returns the bodies of the PATCH and PUT requests an object
+// accepted, oldest first. Out-of-band changes made with Update are not
+// included.
+func (s *Server) Updates(collection string, id int) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var bodies []map[string]interface{}
+	for _, body := range s.updates[collection][id] {
+		bodies = append(bodies, normalize(body).(map[string]interface{}))
+	}
+	return bodies
+}
+
+// roundTrip passes Go values through JSON so that helpers see the same
+// types as request bodies.
+func roundTrip(v map[string]interface{}) interface{} {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out interface{}
+	dec := json.NewDecoder(strings.NewReader(string(b)))
+	dec.UseNumber()
+	dec.Decode(&out)
+	return out
+}
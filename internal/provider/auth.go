@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/dhikrahashim/terraform-provider-aap/internal/client"
+)
+
+// Supported values for the provider's auth_method attribute.
+const (
+	authMethodBasic   = "basic"
+	authMethodToken   = "token"
+	authMethodOAuth2  = "oauth2"
+	authMethodSession = "session"
+)
+
+// oauth2Token is a token acquired by Configure, kept so that every provider
+// configuration for the same host and user in this plugin process shares one
+// token, and so that Close can revoke it.
+type oauth2Token struct {
+	client *client.Client
+	token  *client.OAuth2Token
+}
+
+var oauth2Tokens = struct {
+	sync.Mutex
+	m map[string]*oauth2Token
+}{m: map[string]*oauth2Token{}}
+
+// authenticate prepares c to authenticate with method. For oauth2 the
+// username and password are exchanged for a personal access token, reusing
+// one acquired earlier in this process when possible. For session they are
+// used to log in to the gateway.
+func authenticate(ctx context.Context, c *client.Client, method string) error {
+	switch method {
+	case authMethodBasic:
+		if c.Username == "" || c.Password == "" {
+			return fmt.Errorf("auth_method %q requires username and password", method)
+		}
+		c.Token = ""
+	case authMethodToken:
+		if c.Token == "" {
+			return fmt.Errorf("auth_method %q requires token", method)
+		}
+	case authMethodOAuth2:
+		key := c.Host + "\x00" + c.Username
+		oauth2Tokens.Lock()
+		defer oauth2Tokens.Unlock()
+		cached, ok := oauth2Tokens.m[key]
+		if ok && cached.client.Password == c.Password {
+			c.Token = cached.token.Token
+			return nil
+		}
+		tok, err := c.AcquireOAuth2Token(ctx, fmt.Sprintf("Terraform AAP provider (pid %d)", os.Getpid()))
+		if err != nil {
+			return err
+		}
+		// The password changed: the token acquired with the old one is no
+		// longer shared, so revoke it rather than leave it behind.
+		if ok {
+			if err := cached.revoke(ctx); err != nil {
+				tflog.Warn(ctx, err.Error())
+			}
+		}
+		oauth2Tokens.m[key] = &oauth2Token{client: c, token: tok}
+	case authMethodSession:
+		c.Token = ""
+		return c.Login(ctx)
+	default:
+		return fmt.Errorf("unsupported auth_method %q, expected one of %q, %q, %q or %q", method, authMethodBasic, authMethodToken, authMethodOAuth2, authMethodSession)
+	}
+	return nil
+}
+
+// revoke deletes the token without retrying, so that an unreachable server
+// does not hold up configuration or shutdown. A token that is already gone
+// is not an error.
+func (t *oauth2Token) revoke(ctx context.Context) error {
+	err := t.client.RevokeOAuth2Token(client.WithoutRetries(ctx), t.token)
+	if err != nil && !client.IsNotFound(err) {
+		return fmt.Errorf("unable to revoke OAuth2 token for %s: %w", t.client.Username, err)
+	}
+	return nil
+}
+
+// closeTimeout bounds token revocation in Close. go-plugin kills the plugin
+// two seconds after asking it to stop, so revocation must finish well before
+// then; a token it cannot revoke in time is left to expire on the server.
+const closeTimeout = 1500 * time.Millisecond
+
+// Close revokes OAuth2 tokens acquired by Configure, concurrently and within
+// closeTimeout. main calls it once the plugin server has stopped, so the
+// clients are no longer in use.
+func Close(ctx context.Context) error {
+	oauth2Tokens.Lock()
+	defer oauth2Tokens.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, closeTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+	for key, t := range oauth2Tokens.m {
+		t.client.HTTP.Timeout = closeTimeout
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := t.revoke(ctx); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+		delete(oauth2Tokens.m, key)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dhikrahashim/terraform-provider-aap/internal/client"
+)
+
+func TestAuthenticateOAuth2RevokesReplacedToken(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+	t.Cleanup(func() { Close(ctx) })
+
+	newClient := func() *client.Client {
+		c := client.NewClient(s.URL, s.Username, s.Password, "", false)
+		c.APIPath = s.APIPath
+		return c
+	}
+
+	first := newClient()
+	if err := authenticate(ctx, first, authMethodOAuth2); err != nil {
+		t.Fatalf("authenticate failed: %s", err)
+	}
+	shared := newClient()
+	if err := authenticate(ctx, shared, authMethodOAuth2); err != nil {
+		t.Fatalf("authenticate failed: %s", err)
+	}
+	if shared.Token != first.Token {
+		t.Errorf("Expected the token to be shared, got %q and %q", first.Token, shared.Token)
+	}
+
+	s.Password = "rotated"
+	second := newClient()
+	if err := authenticate(ctx, second, authMethodOAuth2); err != nil {
+		t.Fatalf("authenticate failed: %s", err)
+	}
+	if second.Token == first.Token {
+		t.Fatal("Expected a new token after the password changed")
+	}
+	var apiErr *client.APIError
+	if _, err := first.Organizations.Get(ctx, 1); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected the old token to be revoked, got %v", err)
+	}
+}
+
+func TestCloseGivesUpBeforeThePluginIsKilled(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	c := client.NewClient(ts.URL, "admin", "secret", "pat", false)
+	oauth2Tokens.Lock()
+	oauth2Tokens.m["hanging"] = &oauth2Token{client: c, token: &client.OAuth2Token{ID: 1, Token: "pat"}}
+	oauth2Tokens.Unlock()
+
+	start := time.Now()
+	if err := Close(context.Background()); err == nil {
+		t.Error("Expected an error for the unrevoked token")
+	}
+	if elapsed := time.Since(start); elapsed >= 2*time.Second {
+		t.Errorf("Expected Close to give up within 2s, took %s", elapsed)
+	}
+}
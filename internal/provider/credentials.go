@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/dhikrahashim/terraform-provider-aap/internal/client"
+)
+
+// organizationDescription documents the organization_id attribute of the
+// credential resources.
+const organizationDescription = "ID of the owning organization. Required when the credential is created; credentials owned by a user or team can be imported and managed without one."
+
+// requireOrganizationOnCreate rejects plans creating a credential without an
+// organization. The controller refuses credentials with no owning
+// organization, user or team, and the provider does not assign user or team
+// owners.
+func requireOrganizationOnCreate(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || (!req.State.Raw.IsNull() && len(resp.RequiresReplace) == 0) {
+		return
+	}
+	var orgID types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("organization_id"), &orgID)...)
+	if orgID.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("organization_id"), "Missing Attribute Configuration",
+			"organization_id must be set when creating a credential: the controller requires every new credential to have an owner.")
+	}
+}
+
+// sendInputs reports whether Update must send a credential's inputs. AAP
+// replaces the whole inputs object on PATCH, so all inputs are sent whenever
+// any of them changes, or a new secret_version asks for the secrets to be
+// sent again.
+func sendInputs(inputsChanged bool, planVersion, stateVersion types.String) bool {
+	return inputsChanged || !planVersion.Equal(stateVersion)
+}
+
+// storedSecretsKey is the private state key listing the secret inputs the
+// controller last reported as set. Only their names are recorded.
+const storedSecretsKey = "stored_secrets"
+
+// setStoredSecrets records which inputs returned by the controller are set
+// secrets, which it reports as $encrypted$.
+func setStoredSecrets(ctx context.Context, private privateData, inputs client.CredentialInputs) diag.Diagnostics {
+	var names []string
+	for key, v := range inputs {
+		if v == encrypted {
+			names = append(names, key)
+		}
+	}
+	slices.Sort(names)
+	b, _ := json.Marshal(names)
+	return private.SetKey(ctx, storedSecretsKey, b)
+}
+
+// keepStoredSecrets adds $encrypted$ to inputs for every secret the
+// controller holds that Terraform does not manage: one missing from both
+// inputs and managed, the secrets in state. Since the whole inputs object is
+// replaced, the controller would otherwise clear such secrets, for instance
+// ones imported as null or sent write-only, whenever another input changes.
+func keepStoredSecrets(ctx context.Context, private privateData, inputs client.CredentialInputs, managed map[string]string) diag.Diagnostics {
+	b, diags := private.GetKey(ctx, storedSecretsKey)
+	var names []string
+	if len(b) == 0 || json.Unmarshal(b, &names) != nil {
+		return diags
+	}
+	for _, key := range names {
+		_, sent := inputs[key]
+		_, inState := managed[key]
+		if !sent && !inState {
+			inputs[key] = encrypted
+		}
+	}
+	return diags
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/dhikrahashim/terraform-provider-aap/internal/client"
+)
+
+// addClientError reports err, returned while performing action (e.g. "Unable
+// to create job template"), on diags. Field validation errors sent by the
+// controller are attached to the schema attribute named in fields, keyed by
+// the API field name; anything else is reported as a general error.
+func addClientError(diags *diag.Diagnostics, action string, err error, fields map[string]string) {
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || len(apiErr.Fields) == 0 {
+		diags.AddError("Client Error", fmt.Sprintf("%s: %s", action, err))
+		return
+	}
+
+	unmapped := append([]string{}, apiErr.NonFieldErrors...)
+	for _, key := range apiErr.FieldNames() {
+		messages := strings.Join(apiErr.Fields[key], " ")
+		attr, ok := fields[key]
+		if !ok {
+			// Errors nested inside a JSON attribute, e.g. "inputs.fields" on a
+			// credential type, belong to the attribute itself.
+			attr, ok = fields[strings.SplitN(key, ".", 2)[0]]
+		}
+		if !ok {
+			unmapped = append(unmapped, fmt.Sprintf("%s: %s", key, messages))
+			continue
+		}
+		diags.AddAttributeError(path.Root(attr), "Invalid Attribute Value", fmt.Sprintf("%s: %s", action, messages))
+	}
+
+	if len(unmapped) > 0 {
+		diags.AddError("Client Error", fmt.Sprintf("%s: %s", action, strings.Join(unmapped, "; ")))
+	}
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/dhikrahashim/terraform-provider-aap/internal/client"
+)
+
+func TestAddClientError(t *testing.T) {
+	fields := map[string]string{
+		"name":            "name",
+		"organization":    "organization_id",
+		"inputs":          "inputs",
+		"inputs.password": "password",
+	}
+	const action = "Unable to create credential"
+
+	cases := []struct {
+		name string
+		err  error
+		want diag.Diagnostics
+	}{
+		{
+			name: "field",
+			err:  &client.APIError{StatusCode: 400, Fields: map[string][]string{"organization": {"This field may not be null."}}},
+			want: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path.Root("organization_id"), "Invalid Attribute Value", action+": This field may not be null."),
+			},
+		},
+		{
+			name: "dotted field",
+			err:  &client.APIError{StatusCode: 400, Fields: map[string][]string{"inputs.password": {"required for Machine"}}},
+			want: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path.Root("password"), "Invalid Attribute Value", action+": required for Machine"),
+			},
+		},
+		{
+			name: "nested in a JSON attribute",
+			err:  &client.APIError{StatusCode: 400, Fields: map[string][]string{"inputs.fields": {"'id' is a required property", "of item 0"}}},
+			want: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path.Root("inputs"), "Invalid Attribute Value", action+": 'id' is a required property of item 0"),
+			},
+		},
+		{
+			name: "unmapped field",
+			err: &client.APIError{StatusCode: 400, Fields: map[string][]string{
+				"name":  {"Credential with this Name already exists."},
+				"bogus": {"Unexpected field."},
+			}},
+			want: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path.Root("name"), "Invalid Attribute Value", action+": Credential with this Name already exists."),
+				diag.NewErrorDiagnostic("Client Error", action+": bogus: Unexpected field."),
+			},
+		},
+		{
+			name: "non-field errors",
+			err: &client.APIError{StatusCode: 400, NonFieldErrors: []string{"Missing 'user', 'team', or 'organization'."},
+				Fields: map[string][]string{"inputs.token.nested": {"Invalid."}}},
+			want: diag.Diagnostics{
+				diag.NewAttributeErrorDiagnostic(path.Root("inputs"), "Invalid Attribute Value", action+": Invalid."),
+				diag.NewErrorDiagnostic("Client Error", action+": Missing 'user', 'team', or 'organization'."),
+			},
+		},
+		{
+			name: "not an API error",
+			err:  errors.New("connection refused"),
+			want: diag.Diagnostics{
+				diag.NewErrorDiagnostic("Client Error", action+": connection refused"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			addClientError(&diags, action, tc.err, fields)
+			if !diags.Equal(tc.want) {
+				t.Errorf("Expected %v, got %v", tc.want, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/dhikrahashim/terraform-provider-aap/internal/client"
+)
+
+// importedKey marks, in private state, a resource imported since its last
+// Read, so that Read can report what the import could not fill in.
+const importedKey = "imported"
+
+// importByIDOrName imports a resource by numeric ID or by a natural key made
+// of slash-separated names. formats describes the accepted keys, e.g.
+// "<organization>/<inventory>"; resolve is called with the key split into
+// its names, with as many names as one of the formats, and returns the ID.
+//
+// Names containing a slash cannot be used in a natural key; such objects
+// must be imported by ID.
+func importByIDOrName(ctx context.Context, c *client.Client, req resource.ImportStateRequest, resp *resource.ImportStateResponse, formats []string, resolve func(ctx context.Context, names []string) (int, error)) {
+	if _, err := strconv.Atoi(req.ID); err == nil {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	names := strings.Split(req.ID, "/")
+	valid := false
+	for _, f := range formats {
+		if strings.Count(f, "/")+1 == len(names) {
+			valid = true
+		}
+	}
+	for _, name := range names {
+		if name == "" {
+			valid = false
+		}
+	}
+	if !valid {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected a numeric ID or %s, got %q.", strings.Join(formats, " or "), req.ID),
+		)
+		return
+	}
+
+	if c == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured Client",
+			"The provider must be configured to import by name. Import by numeric ID instead.",
+		)
+		return
+	}
+
+	id, err := resolve(ctx, names)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import %q: %s", req.ID, err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strconv.Itoa(id))...)
+}
+
+// findID returns the ID of the only object in seq. what describes the
+// search in errors, e.g. `inventory "prod" in organization "Default"`.
+func findID[T any](seq iter.Seq2[T, error], id func(*T) int, what string) (int, error) {
+	var ids []int
+	for obj, err := range seq {
+		if err != nil {
+			return 0, err
+		}
+		ids = append(ids, id(&obj))
+		if len(ids) > 1 {
+			break
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return 0, fmt.Errorf("no %s found", what)
+	case 1:
+		return ids[0], nil
+	}
+	return 0, fmt.Errorf("more than one %s found; import it by numeric ID instead", what)
+}
+
+// findCredentialID returns the ID of the credential of the managed type with
+// the given namespace named by names, which is either the credential name or
+// an organization name and the credential name. label names the credential
+// kind in errors.
+func findCredentialID(ctx context.Context, c *client.Client, namespace, label string, names []string) (int, error) {
+	credentialType, err := c.ManagedCredentialTypeID(ctx, namespace)
+	if err != nil {
+		return 0, err
+	}
+	opts := client.ListOptions{
+		Name:    names[len(names)-1],
+		Filters: map[string]string{"credential_type": strconv.Itoa(credentialType)},
+	}
+	what := fmt.Sprintf("%s %q", label, opts.Name)
+	if len(names) == 2 {
+		opts.OrganizationName = names[0]
+		what += fmt.Sprintf(" in organization %q", names[0])
+	}
+	return findID(c.Credentials.List(ctx, opts), func(cred *client.Credential) int { return cred.ID }, what)
+}
+
+// markImported records that the resource is being imported, for Reads that
+// call justImported. Only resources whose Read clears the mark may set it.
+func markImported(ctx context.Context, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedKey, []byte("true"))...)
+}
+
+// justImported reports whether the resource was imported since its last
+// Read, and clears the mark.
+func justImported(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) bool {
+	v, diags := req.Private.GetKey(ctx, importedKey)
+	resp.Diagnostics.Append(diags...)
+	if len(v) == 0 {
+		return false
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedKey, nil)...)
+	return true
+}
+
+// addUnreadableSecretsWarning tells the user which secret attributes are
+// set on the controller but were imported as null, because the controller
+// only ever returns them masked. secrets maps attribute names to the value
+// returned by the controller, which is empty when the secret is not set.
+func addUnreadableSecretsWarning(diags *diag.Diagnostics, secrets map[string]string) {
+	var set []string
+	for attr, v := range secrets {
+		if v != "" {
+			set = append(set, attr)
+		}
+	}
+	if len(set) == 0 {
+		return
+	}
+	sort.Strings(set)
+	diags.AddWarning(
+		"Secret Attributes Require Configuration",
+		fmt.Sprintf("The controller stores a value for each of: %s. It never returns secret values, so these "+
+			"attributes were imported as null. Set them in configuration for Terraform to manage them; while "+
+			"they are null, the provider asks the controller to keep the stored values.", strings.Join(set, ", ")),
+	)
+}
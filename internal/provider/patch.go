@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// patchBuilder collects the API fields whose planned value differs from the
+// prior state. Every transition is recorded, including to false, zero and
+// the empty string, so an Update PATCH always carries what changed and
+// nothing else.
+type patchBuilder map[string]interface{}
+
+// String records field when plan differs from state. A null plan value
+// clears the field.
+func (p patchBuilder) String(field string, plan, state types.String) {
+	if plan.IsUnknown() || plan.Equal(state) {
+		return
+	}
+	p[field] = plan.ValueString()
+}
+
+// Bool records field when plan differs from state. A null plan value resets
+// the field to false.
+func (p patchBuilder) Bool(field string, plan, state types.Bool) {
+	if plan.IsUnknown() || plan.Equal(state) {
+		return
+	}
+	p[field] = plan.ValueBool()
+}
+
+// Int64 records field when plan differs from state. A null plan value
+// resets the field to 0.
+func (p patchBuilder) Int64(field string, plan, state types.Int64) {
+	if plan.IsUnknown() || plan.Equal(state) {
+		return
+	}
+	p[field] = plan.ValueInt64()
+}
+
+// ID records a reference to another object, given as a string ID in the
+// schema, when plan differs from state. A null or empty plan value sends
+// JSON null to detach the reference.
+func (p patchBuilder) ID(field string, plan, state types.String) {
+	if plan.IsUnknown() || plan.Equal(state) {
+		return
+	}
+	if plan.IsNull() || plan.ValueString() == "" {
+		p[field] = nil
+		return
+	}
+	if id, err := strconv.Atoi(plan.ValueString()); err == nil {
+		p[field] = id
+		return
+	}
+	// Let the controller reject malformed IDs so the error is reported
+	// against the attribute.
+	p[field] = plan.ValueString()
+}
+
+// JSON records a field holding a JSON document, given as a string in the
+// schema, when plan differs from state. A null plan value resets the field
+// to an empty object.
+func (p patchBuilder) JSON(field string, plan, state types.String) {
+	if plan.IsUnknown() || plan.Equal(state) {
+		return
+	}
+	if plan.IsNull() {
+		p[field] = map[string]interface{}{}
+		return
+	}
+	p[field] = json.RawMessage(plan.ValueString())
+}
+
+// jsonBody converts a JSON document given as a string in the schema for an
+// API request body. A null value is omitted.
+func jsonBody(v types.String) json.RawMessage {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	return json.RawMessage(v.ValueString())
+}
+
+// jsonValue converts a JSON document returned by the controller for storage
+// in state. The prior value is kept when it is semantically equal, so that
+// formatting and key order in the configuration do not drift, and an empty
+// object is kept null when the prior value was null.
+func jsonValue(prior types.String, raw json.RawMessage) types.String {
+	var got interface{}
+	if len(raw) == 0 || json.Unmarshal(raw, &got) != nil {
+		return prior
+	}
+	if m, ok := got.(map[string]interface{}); ok && len(m) == 0 && prior.IsNull() {
+		return types.StringNull()
+	}
+	var want interface{}
+	if !prior.IsNull() && json.Unmarshal([]byte(prior.ValueString()), &want) == nil && reflect.DeepEqual(got, want) {
+		return prior
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return types.StringValue(string(raw))
+	}
+	return types.StringValue(buf.String())
+}
+
+// stringValue converts a string returned by the controller for storage in
+// state. The controller reports unset strings as "", which is kept null
+// when the prior value was null so that unset attributes do not drift.
+func stringValue(prior types.String, v string) types.String {
+	if v == "" && prior.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(v)
+}
+
+// boolValue is the types.Bool counterpart of stringValue.
+func boolValue(prior types.Bool, v bool) types.Bool {
+	if !v && prior.IsNull() {
+		return types.BoolNull()
+	}
+	return types.BoolValue(v)
+}
+
+// int64Value is the types.Int64 counterpart of stringValue.
+func int64Value(prior types.Int64, v int) types.Int64 {
+	if v == 0 && prior.IsNull() {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(v))
+}
+
+// idValue converts a reference to another object for storage in state. The
+// controller reports a missing reference as null, decoded as 0.
+func idValue(id int) types.String {
+	if id == 0 {
+		return types.StringNull()
+	}
+	return types.StringValue(strconv.Itoa(id))
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/dhikrahashim/terraform-provider-aap/internal/fakeaap"
+)
+
+// testAccProtoV6ProviderFactories instantiates the provider in-process for
+// acceptance tests.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"aap": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// newTestServer starts a fake controller that is shut down with the test.
+func newTestServer(t *testing.T) *fakeaap.Server {
+	t.Helper()
+	s := fakeaap.New()
+	t.Cleanup(s.Close)
+	return s
+}
+
+// testAccProviderConfig returns a provider block for s. Acceptance test
+// configs start with it.
+func testAccProviderConfig(s *fakeaap.Server) string {
+	return fmt.Sprintf(`
+provider "aap" {
+  host        = %q
+  username    = %q
+  password    = %q
+  max_retries = 0
+}
+`, s.URL, s.Username, s.Password)
+}
+
+// testAccCaptureID stores the numeric ID of a resource in id, for deleting
+// it out of band in a later step.
+func testAccCaptureID(name string, id *int) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		n, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("resource %s has non-numeric ID %q", name, rs.Primary.ID)
+		}
+		*id = n
+		return nil
+	}
+}
+
+// testAccDeleteOutOfBand returns a PreConfig function that deletes the object
+// captured in id directly on the server.
+func testAccDeleteOutOfBand(t *testing.T, s *fakeaap.Server, collection string, id *int) func() {
+	return func() {
+		if err := s.Delete(collection, *id); err != nil {
+			t.Fatalf("deleting %s %d: %s", collection, *id, err)
+		}
+	}
+}
+
+// testAccCheckDestroyed verifies that no objects of collection remain.
+func testAccCheckDestroyed(s *fakeaap.Server, collection string) func(*terraform.State) error {
+	return func(*terraform.State) error {
+		if n := s.Count(collection); n != 0 {
+			return fmt.Errorf("%d %s still exist", n, collection)
+		}
+		return nil
+	}
+}
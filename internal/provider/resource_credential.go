@@ -0,0 +1,602 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/dhikrahashim/terraform-provider-aap/internal/client"
+)
+
+var _ resource.Resource = &CredentialResource{}
+var _ resource.ResourceWithImportState = &CredentialResource{}
+var _ resource.ResourceWithValidateConfig = &CredentialResource{}
+var _ resource.ResourceWithModifyPlan = &CredentialResource{}
+
+func NewCredentialResource() resource.Resource {
+	return &CredentialResource{}
+}
+
+type CredentialResource struct {
+	client *client.Client
+}
+
+type CredentialResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	Description        types.String   `tfsdk:"description"`
+	OrganizationID     types.String   `tfsdk:"organization_id"`
+	CredentialTypeID   types.String   `tfsdk:"credential_type_id"`
+	CredentialTypeName types.String   `tfsdk:"credential_type_name"`
+	Inputs             types.Map      `tfsdk:"inputs"`
+	SecretInputs       types.Map      `tfsdk:"secret_inputs"`
+	SecretVersion      types.String   `tfsdk:"secret_version"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// credentialAPIFields maps controller field names to schema attributes for
+// validation errors. Errors for individual inputs are attached by
+// addCredentialError.
+var credentialAPIFields = map[string]string{
+	"name":            "name",
+	"description":     "description",
+	"organization":    "organization_id",
+	"credential_type": "credential_type_id",
+	"inputs":          "inputs",
+}
+
+// encrypted is returned by the controller in place of secret inputs.
+const encrypted = "$encrypted$"
+
+func (r *CredentialResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_credential"
+}
+
+func (r *CredentialResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Credential of any type, including custom credential types.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+			},
+			"organization_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: organizationDescription,
+			},
+			"credential_type_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "ID of the credential type. Exactly one of `credential_type_id` and `credential_type_name` must be set. Changing the type replaces the credential.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"credential_type_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Name of the credential type, e.g. `Amazon Web Services`, resolved to `credential_type_id` when planning.",
+			},
+			"inputs": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Non-secret inputs keyed by the credential type's field IDs. Boolean fields take `\"true\"` or `\"false\"`.",
+			},
+			"secret_inputs": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Secret inputs keyed by the credential type's field IDs.",
+			},
+			"secret_version": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: secretVersionDescription,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *CredentialResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData))
+		return
+	}
+	r.client = c
+}
+
+// ValidateConfig requires exactly one way of naming the credential type and
+// rejects inputs given in both input maps.
+func (r *CredentialResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data CredentialResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case data.CredentialTypeID.IsNull() && data.CredentialTypeName.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("credential_type_id"), "Missing Attribute Configuration",
+			"Exactly one of credential_type_id and credential_type_name must be set.")
+	case !data.CredentialTypeID.IsNull() && !data.CredentialTypeName.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("credential_type_name"), "Invalid Attribute Combination",
+			"Exactly one of credential_type_id and credential_type_name must be set.")
+	}
+
+	if data.Inputs.IsUnknown() || data.SecretInputs.IsUnknown() {
+		return
+	}
+	for key := range data.SecretInputs.Elements() {
+		if _, ok := data.Inputs.Elements()[key]; ok {
+			resp.Diagnostics.AddAttributeError(path.Root("secret_inputs").AtMapKey(key), "Invalid Attribute Combination",
+				fmt.Sprintf("Input %q is set in both inputs and secret_inputs.", key))
+		}
+	}
+}
+
+// ModifyPlan resolves credential_type_name to its ID, so that a change of
+// type is planned as a replacement, and validates the inputs against the
+// credential type and the organization so that mistakes are reported before
+// apply.
+func (r *CredentialResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	var plan CredentialResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.CredentialTypeName.IsNull() {
+		typeID := types.StringUnknown()
+		if !plan.CredentialTypeName.IsUnknown() {
+			id, err := r.findCredentialType(ctx, plan.CredentialTypeName.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("credential_type_name"), "Invalid Attribute Value", err.Error())
+				return
+			}
+			typeID = types.StringValue(strconv.Itoa(id))
+		}
+		plan.CredentialTypeID = typeID
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("credential_type_id"), typeID)...)
+	}
+
+	if !plan.CredentialTypeID.IsUnknown() {
+		typeID, _ := strconv.Atoi(plan.CredentialTypeID.ValueString())
+		ct, err := r.client.CredentialTypes.Get(ctx, typeID)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("credential_type_id"), "Invalid Attribute Value",
+				fmt.Sprintf("Unable to read credential type: %s", err))
+			return
+		}
+		resp.Diagnostics.Append(plan.validateInputs(ct)...)
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state CredentialResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if !plan.CredentialTypeID.Equal(state.CredentialTypeID) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("credential_type_id"))
+		}
+	}
+	requireOrganizationOnCreate(ctx, req, resp)
+}
+
+func (r *CredentialResource) findCredentialType(ctx context.Context, name string) (int, error) {
+	return findID(r.client.CredentialTypes.List(ctx, client.ListOptions{Name: name}),
+		func(ct *client.CredentialType) int { return ct.ID },
+		fmt.Sprintf("credential type %q", name))
+}
+
+// inputs merges the inputs and secret inputs of m for the API, converting
+// the values of boolean fields declared by ct.
+func (m CredentialResourceModel) inputs(ct *client.CredentialType) (client.CredentialInputs, diag.Diagnostics) {
+	diags := m.validateInputs(ct)
+	if diags.HasError() {
+		return nil, diags
+	}
+	schema, _ := ct.InputSchema()
+
+	inputs := client.CredentialInputs{}
+	for _, values := range []types.Map{m.Inputs, m.SecretInputs} {
+		for key, v := range values.Elements() {
+			s := v.(types.String).ValueString()
+			if schema.Field(key).Type == "boolean" {
+				inputs[key] = s == "true"
+				continue
+			}
+			inputs[key] = s
+		}
+	}
+	return inputs, diags
+}
+
+// validateInputs checks the inputs of m against the input schema of ct:
+// every key must be a field of the type, secret fields must be given in
+// secret_inputs, values must match the field's type and choices, and
+// required fields must be set. Unknown values are skipped.
+func (m CredentialResourceModel) validateInputs(ct *client.CredentialType) diag.Diagnostics {
+	var diags diag.Diagnostics
+	schema, err := ct.InputSchema()
+	if err != nil {
+		diags.AddAttributeError(path.Root("credential_type_id"), "Invalid Credential Type", err.Error())
+		return diags
+	}
+
+	ids := make([]string, len(schema.Fields))
+	for i, f := range schema.Fields {
+		ids[i] = f.ID
+	}
+	for _, attr := range []string{"inputs", "secret_inputs"} {
+		values := m.Inputs
+		if attr == "secret_inputs" {
+			values = m.SecretInputs
+		}
+		elements := values.Elements()
+		keys := slices.Sorted(maps.Keys(elements))
+		for _, key := range keys {
+			p := path.Root(attr).AtMapKey(key)
+			f := schema.Field(key)
+			if f == nil {
+				diags.AddAttributeError(p, "Invalid Attribute Value",
+					fmt.Sprintf("Credential type %q has no input %q. Its inputs are: %s.", ct.Name, key, strings.Join(ids, ", ")))
+				continue
+			}
+			if f.Secret && attr == "inputs" {
+				// inputs is not sensitive, so its values would be shown in
+				// plans and kept in state unmasked.
+				diags.AddAttributeError(p, "Invalid Attribute Value",
+					fmt.Sprintf("Input %q of credential type %q is secret; set it in secret_inputs instead.", key, ct.Name))
+				continue
+			}
+			v := elements[key].(types.String)
+			if v.IsUnknown() || v.IsNull() {
+				continue
+			}
+			s := v.ValueString()
+			if f.Type == "boolean" {
+				// Only the spellings the controller's values are read back as,
+				// so that the configuration matches the state.
+				if s != "true" && s != "false" {
+					diags.AddAttributeError(p, "Invalid Attribute Value",
+						fmt.Sprintf("Input %q of credential type %q is a boolean and must be \"true\" or \"false\", got %q.", key, ct.Name, s))
+				}
+				continue
+			}
+			if len(f.Choices) > 0 && !slices.Contains(f.Choices, s) {
+				diags.AddAttributeError(p, "Invalid Attribute Value",
+					fmt.Sprintf("Input %q of credential type %q must be one of %s, got %q.", key, ct.Name, strings.Join(f.Choices, ", "), s))
+			}
+		}
+	}
+
+	if m.Inputs.IsUnknown() || m.SecretInputs.IsUnknown() {
+		return diags
+	}
+	for _, key := range schema.Required {
+		v, ok := m.Inputs.Elements()[key]
+		if !ok {
+			v, ok = m.SecretInputs.Elements()[key]
+		}
+		if ok && !v.IsNull() && (v.IsUnknown() || v.(types.String).ValueString() != "") {
+			continue
+		}
+		attr := "inputs"
+		if f := schema.Field(key); f != nil && f.Secret {
+			attr = "secret_inputs"
+		}
+		diags.AddAttributeError(path.Root(attr), "Missing Required Input",
+			fmt.Sprintf("Credential type %q requires input %q.", ct.Name, key))
+	}
+	return diags
+}
+
+// secrets returns the secret inputs of m.
+func (m CredentialResourceModel) secrets() map[string]string {
+	secrets := map[string]string{}
+	for key, v := range m.SecretInputs.Elements() {
+		secrets[key] = v.(types.String).ValueString()
+	}
+	return secrets
+}
+
+// inputValue converts an input returned by the controller to its string
+// form in the inputs map.
+func inputValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// readInputs updates the input maps of m from the inputs returned by the
+// controller. Secret inputs come back masked: a configured secret is kept
+// while the controller still has a value for it, and dropped otherwise.
+func (m *CredentialResourceModel) readInputs(got client.CredentialInputs) {
+	priorSecrets := m.SecretInputs.Elements()
+
+	inputs := map[string]string{}
+	for key, v := range got {
+		if _, ok := priorSecrets[key]; ok {
+			continue
+		}
+		if v == encrypted {
+			continue
+		}
+		inputs[key] = inputValue(v)
+	}
+	secrets := map[string]string{}
+	for key, prior := range priorSecrets {
+		if _, ok := got[key]; ok {
+			secrets[key] = prior.(types.String).ValueString()
+		}
+	}
+
+	m.Inputs = mapValue(m.Inputs, inputs)
+	m.SecretInputs = mapValue(m.SecretInputs, secrets)
+}
+
+// mapValue is the types.Map counterpart of stringValue for maps of strings.
+func mapValue(prior types.Map, v map[string]string) types.Map {
+	if len(v) == 0 && prior.IsNull() {
+		return types.MapNull(types.StringType)
+	}
+	m, _ := types.MapValueFrom(context.Background(), types.StringType, v)
+	return m
+}
+
+// addCredentialError reports err like addClientError, attaching errors for
+// individual inputs to their key in inputs or secret_inputs.
+func addCredentialError(diags *diag.Diagnostics, action string, err error, m CredentialResourceModel) {
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || len(apiErr.Fields) == 0 {
+		addClientError(diags, action, err, credentialAPIFields)
+		return
+	}
+
+	rest := *apiErr
+	rest.Fields = map[string][]string{}
+	for _, key := range apiErr.FieldNames() {
+		input, ok := strings.CutPrefix(key, "inputs.")
+		if !ok {
+			rest.Fields[key] = apiErr.Fields[key]
+			continue
+		}
+		attr := "inputs"
+		if _, ok := m.SecretInputs.Elements()[input]; ok {
+			attr = "secret_inputs"
+		}
+		diags.AddAttributeError(path.Root(attr).AtMapKey(input), "Invalid Attribute Value",
+			fmt.Sprintf("%s: %s", action, strings.Join(apiErr.Fields[key], " ")))
+	}
+
+	if len(rest.Fields) > 0 {
+		addClientError(diags, action, &rest, credentialAPIFields)
+	} else if len(rest.NonFieldErrors) > 0 {
+		diags.AddError("Client Error", fmt.Sprintf("%s: %s", action, strings.Join(rest.NonFieldErrors, "; ")))
+	}
+}
+
+func (r *CredentialResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CredentialResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	if data.CredentialTypeID.IsUnknown() {
+		id, err := r.findCredentialType(ctx, data.CredentialTypeName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("credential_type_name"), "Invalid Attribute Value", err.Error())
+			return
+		}
+		data.CredentialTypeID = types.StringValue(strconv.Itoa(id))
+	}
+	typeID, _ := strconv.Atoi(data.CredentialTypeID.ValueString())
+	ct, err := r.client.CredentialTypes.Get(ctx, typeID)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read credential type", err, nil)
+		return
+	}
+	inputs, diags := data.inputs(ct)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgID, _ := strconv.Atoi(data.OrganizationID.ValueString())
+	cred := &client.Credential{
+		Name:           data.Name.ValueString(),
+		Description:    data.Description.ValueString(),
+		Organization:   orgID,
+		CredentialType: typeID,
+		Inputs:         inputs,
+	}
+
+	created, err := r.client.Credentials.Create(ctx, cred)
+	if err != nil {
+		addCredentialError(&resp.Diagnostics, "Unable to create credential", err, data)
+		return
+	}
+
+	data.ID = types.StringValue(strconv.Itoa(created.ID))
+	resp.Diagnostics.Append(setSecretsRecord(ctx, resp.Private, data.secrets(), nil, created.Modified)...)
+	resp.Diagnostics.Append(setStoredSecrets(ctx, resp.Private, created.Inputs)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CredentialResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CredentialResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	id, _ := strconv.Atoi(data.ID.ValueString())
+	cred, err := r.client.Credentials.Get(ctx, id)
+	if err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read credential: %s", err))
+		return
+	}
+
+	data.Name = types.StringValue(cred.Name)
+	data.Description = stringValue(data.Description, cred.Description)
+	data.OrganizationID = idValue(cred.Organization)
+	data.CredentialTypeID = idValue(cred.CredentialType)
+	data.readInputs(cred.Inputs)
+	resp.Diagnostics.Append(setStoredSecrets(ctx, resp.Private, cred.Inputs)...)
+	if secretsDrifted(ctx, req, resp, cred.Name, data.secrets(), cred.Modified) {
+		data.SecretInputs = types.MapNull(types.StringType)
+	}
+	if justImported(ctx, req, resp) {
+		secrets := map[string]string{}
+		for key, v := range cred.Inputs {
+			if v == encrypted {
+				secrets["secret_inputs."+key] = encrypted
+			}
+		}
+		addUnreadableSecretsWarning(&resp.Diagnostics, secrets)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CredentialResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state CredentialResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	id, _ := strconv.Atoi(data.ID.ValueString())
+
+	patch := patchBuilder{}
+	patch.String("name", data.Name, state.Name)
+	patch.String("description", data.Description, state.Description)
+	patch.ID("organization", data.OrganizationID, state.OrganizationID)
+	if sendInputs(!data.Inputs.Equal(state.Inputs) || !data.SecretInputs.Equal(state.SecretInputs), data.SecretVersion, state.SecretVersion) {
+		typeID, _ := strconv.Atoi(data.CredentialTypeID.ValueString())
+		ct, err := r.client.CredentialTypes.Get(ctx, typeID)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "Unable to read credential type", err, nil)
+			return
+		}
+		inputs, diags := data.inputs(ct)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(keepStoredSecrets(ctx, req.Private, inputs, state.secrets())...)
+		patch["inputs"] = inputs
+	}
+
+	updated, err := r.client.Credentials.Patch(ctx, id, patch)
+	if err != nil {
+		addCredentialError(&resp.Diagnostics, "Unable to update credential", err, data)
+		return
+	}
+	resp.Diagnostics.Append(setSecretsRecord(ctx, resp.Private, data.secrets(), nil, updated.Modified)...)
+	resp.Diagnostics.Append(setStoredSecrets(ctx, resp.Private, updated.Inputs)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CredentialResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data CredentialResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	id, _ := strconv.Atoi(data.ID.ValueString())
+	if err := r.client.Credentials.Delete(ctx, id); err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete credential: %s", err))
+	}
+}
+
+func (r *CredentialResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	markImported(ctx, resp)
+	importByIDOrName(ctx, r.client, req, resp, []string{"<name>", "<organization>/<name>"}, func(ctx context.Context, names []string) (int, error) {
+		opts := client.ListOptions{Name: names[len(names)-1]}
+		what := fmt.Sprintf("credential %q", opts.Name)
+		if len(names) == 2 {
+			opts.OrganizationName = names[0]
+			what += fmt.Sprintf(" in organization %q", names[0])
+		}
+		return findID(r.client.Credentials.List(ctx, opts), func(c *client.Credential) int { return c.ID }, what)
+	})
+}
+
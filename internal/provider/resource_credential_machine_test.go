@@ -0,0 +1,282 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCredentialMachineResource(t *testing.T) {
+	s := newTestServer(t)
+	var id int
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(s, "credentials"),
+		Steps: []resource.TestStep{
+			// Create
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialMachineConfig("Deploy", `
+  description     = "Deploy user"
+  username        = "deploy"
+  password        = "hunter2"
+  become_method   = "sudo"
+  become_username = "root"
+  become_password = "root-password"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("aap_credential_machine.test", "id"),
+					resource.TestCheckResourceAttr("aap_credential_machine.test", "name", "Deploy"),
+					resource.TestCheckResourceAttr("aap_credential_machine.test", "username", "deploy"),
+					resource.TestCheckResourceAttr("aap_credential_machine.test", "password", "hunter2"),
+					resource.TestCheckResourceAttr("aap_credential_machine.test", "become_method", "sudo"),
+					testAccCaptureID("aap_credential_machine.test", &id),
+					func(*terraform.State) error {
+						inputs := s.Object("credentials", id)["inputs"].(map[string]interface{})
+						if inputs["password"] != "hunter2" || inputs["become_password"] != "root-password" {
+							return fmt.Errorf("expected secrets to be stored, got %v", inputs)
+						}
+						return nil
+					},
+				),
+			},
+			// Import. Secrets are write-only in the API.
+			{
+				ResourceName:            "aap_credential_machine.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts", "password", "become_password"},
+			},
+			// Import by name
+			{
+				ResourceName:            "aap_credential_machine.test",
+				ImportState:             true,
+				ImportStateId:           "Credential Org/Deploy",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts", "password", "become_password"},
+			},
+			// Update in place, including a secret
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialMachineConfig("Deploy user", `
+  description     = "Deploy user"
+  username        = "ops"
+  password        = "correct-horse"
+  become_method   = "sudo"
+  become_username = "root"
+  become_password = "root-password"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aap_credential_machine.test", "name", "Deploy user"),
+					resource.TestCheckResourceAttr("aap_credential_machine.test", "username", "ops"),
+					func(*terraform.State) error {
+						inputs := s.Object("credentials", id)["inputs"].(map[string]interface{})
+						if inputs["password"] != "correct-horse" || inputs["become_password"] != "root-password" {
+							return fmt.Errorf("expected updated secrets, got %v", inputs)
+						}
+						return nil
+					},
+				),
+			},
+			// Clear optional attributes
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialMachineConfig("Deploy user", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("aap_credential_machine.test", "description"),
+					resource.TestCheckNoResourceAttr("aap_credential_machine.test", "username"),
+					resource.TestCheckNoResourceAttr("aap_credential_machine.test", "password"),
+					func(*terraform.State) error {
+						if inputs := s.Object("credentials", id)["inputs"].(map[string]interface{}); len(inputs) != 0 {
+							return fmt.Errorf("expected inputs to be cleared, got %v", inputs)
+						}
+						return nil
+					},
+				),
+			},
+			// Deleted outside Terraform
+			{
+				PreConfig:          testAccDeleteOutOfBand(t, s, "credentials", &id),
+				Config:             testAccProviderConfig(s) + testAccCredentialMachineConfig("Deploy user", ""),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Recreate
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialMachineConfig("Deploy user", ""),
+				Check:  resource.TestCheckResourceAttr("aap_credential_machine.test", "name", "Deploy user"),
+			},
+		},
+	})
+}
+
+func testAccCredentialMachineConfig(name, attrs string) string {
+	return testAccOrganizationConfig("Credential Org", "") + fmt.Sprintf(`
+resource "aap_credential_machine" "test" {
+  name            = %q
+  organization_id = aap_organization.test.id
+%s}
+`, name, attrs)
+}
+
+func TestAccCredentialMachineResource_secretDrift(t *testing.T) {
+	s := newTestServer(t)
+	var id int
+	rotate := func() {
+		if err := s.Update("credentials", id, map[string]interface{}{
+			"inputs": map[string]interface{}{"username": "deploy", "password": "rotated"},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkPassword := func(want string) func(*terraform.State) error {
+		return func(*terraform.State) error {
+			if inputs := s.Object("credentials", id)["inputs"].(map[string]interface{}); inputs["password"] != want {
+				return fmt.Errorf("expected password %q, got %v", want, inputs)
+			}
+			return nil
+		}
+	}
+	config := func(version string) string {
+		return testAccProviderConfig(s) + testAccCredentialMachineConfig("Deploy", fmt.Sprintf(`
+  username       = "deploy"
+  password       = "hunter2"
+  secret_version = %q
+`, version))
+	}
+	// inputsSent counts the updates that sent the credential's inputs.
+	inputsSent := func() int {
+		n := 0
+		for _, body := range s.Updates("credentials", id) {
+			if _, ok := body["inputs"]; ok {
+				n++
+			}
+		}
+		return n
+	}
+	var sent int
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("1"),
+				Check:  testAccCaptureID("aap_credential_machine.test", &id),
+			},
+			// Password rotated outside Terraform
+			{
+				PreConfig:          rotate,
+				Config:             config("1"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("1"),
+				Check:  checkPassword("hunter2"),
+			},
+			// A new secret_version sends the password again, with no drift
+			{
+				PreConfig: func() { sent = inputsSent() },
+				Config:    config("2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aap_credential_machine.test", "secret_version", "2"),
+					func(*terraform.State) error {
+						if inputsSent() != sent+1 {
+							return fmt.Errorf("expected the inputs to be sent once, sent %d times", inputsSent()-sent)
+						}
+						return nil
+					},
+					checkPassword("hunter2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCredentialMachineResource_writeOnly(t *testing.T) {
+	s := newTestServer(t)
+	var id int
+	checkPassword := func(want string) func(*terraform.State) error {
+		return func(*terraform.State) error {
+			if inputs := s.Object("credentials", id)["inputs"].(map[string]interface{}); inputs["password"] != want {
+				return fmt.Errorf("expected password %q, got %v", want, inputs)
+			}
+			return nil
+		}
+	}
+	config := func(password, version string) string {
+		return testAccProviderConfig(s) + testAccCredentialMachineConfig("Deploy", fmt.Sprintf(`
+  username       = "deploy"
+  password_wo    = %q
+  secret_version = %q
+`, password, version))
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialMachineConfig("Deploy", `
+  password       = "hunter2"
+  password_wo    = "hunter2"
+  secret_version = "1"
+`),
+				ExpectError: regexp.MustCompile(`Only one of password and password_wo may be set`),
+			},
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialMachineConfig("Deploy", `
+  password_wo = "hunter2"
+`),
+				ExpectError: regexp.MustCompile(`secret_version must be set when write-only secrets are used`),
+			},
+			{
+				Config: config("hunter2", "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCaptureID("aap_credential_machine.test", &id),
+					resource.TestCheckNoResourceAttr("aap_credential_machine.test", "password_wo"),
+					resource.TestCheckNoResourceAttr("aap_credential_machine.test", "password"),
+					checkPassword("hunter2"),
+				),
+			},
+			// A changed write-only secret is only sent with a new secret_version
+			{
+				Config: config("correct-horse", "1"),
+				Check:  checkPassword("hunter2"),
+			},
+			{
+				Config: config("correct-horse", "2"),
+				Check:  checkPassword("correct-horse"),
+			},
+			// Password rotated outside Terraform
+			{
+				PreConfig: func() {
+					if err := s.Update("credentials", id, map[string]interface{}{
+						"inputs": map[string]interface{}{"username": "deploy", "password": "rotated"},
+					}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             config("correct-horse", "2"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("correct-horse", "2"),
+				Check:  checkPassword("correct-horse"),
+			},
+			// Removing the write-only secret keeps the stored password
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialMachineConfig("Deploy", `
+  username       = "ops"
+  secret_version = "2"
+`),
+				Check: checkPassword("correct-horse"),
+			},
+		},
+	})
+}
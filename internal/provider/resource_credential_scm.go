@@ -3,9 +3,10 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
 	"strconv"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -16,6 +17,8 @@ import (
 
 var _ resource.Resource = &CredentialScmResource{}
 var _ resource.ResourceWithImportState = &CredentialScmResource{}
+var _ resource.ResourceWithValidateConfig = &CredentialScmResource{}
+var _ resource.ResourceWithModifyPlan = &CredentialScmResource{}
 
 func NewCredentialScmResource() resource.Resource {
 	return &CredentialScmResource{}
@@ -26,14 +29,80 @@ type CredentialScmResource struct {
 }
 
 type CredentialScmResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	Name           types.String `tfsdk:"name"`
-	Description    types.String `tfsdk:"description"`
-	OrganizationID types.String `tfsdk:"organization_id"`
-	Username       types.String `tfsdk:"username"`
-	Password       types.String `tfsdk:"password"`
-	SSHKeyData     types.String `tfsdk:"ssh_key_data"`
-	SSHKeyUnlock   types.String `tfsdk:"ssh_key_unlock"`
+	ID             types.String   `tfsdk:"id"`
+	Name           types.String   `tfsdk:"name"`
+	Description    types.String   `tfsdk:"description"`
+	OrganizationID types.String   `tfsdk:"organization_id"`
+	Username       types.String   `tfsdk:"username"`
+	Password       types.String   `tfsdk:"password"`
+	SSHKeyData     types.String   `tfsdk:"ssh_key_data"`
+	SSHKeyUnlock   types.String   `tfsdk:"ssh_key_unlock"`
+	PasswordWO     types.String   `tfsdk:"password_wo"`
+	SSHKeyDataWO   types.String   `tfsdk:"ssh_key_data_wo"`
+	SSHKeyUnlockWO types.String   `tfsdk:"ssh_key_unlock_wo"`
+	SecretVersion  types.String   `tfsdk:"secret_version"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// credentialScmAPIFields maps controller field names to schema attributes for validation errors.
+var credentialScmAPIFields = map[string]string{
+	"name":                  "name",
+	"description":           "description",
+	"organization":          "organization_id",
+	"inputs.username":       "username",
+	"inputs.password":       "password",
+	"inputs.ssh_key_data":   "ssh_key_data",
+	"inputs.ssh_key_unlock": "ssh_key_unlock",
+}
+
+// inputs returns the credential inputs described by m.
+func (m CredentialScmResourceModel) inputs() client.CredentialInputs {
+	inputs := client.CredentialInputs{}
+	if m.Username.ValueString() != "" {
+		inputs["username"] = m.Username.ValueString()
+	}
+	for key, v := range m.secrets() {
+		inputs[key] = v
+	}
+	return inputs
+}
+
+// secrets returns the secret inputs described by m.
+func (m CredentialScmResourceModel) secrets() map[string]string {
+	secrets := map[string]string{}
+	for key, v := range map[string]types.String{
+		"password":       m.Password,
+		"ssh_key_data":   m.SSHKeyData,
+		"ssh_key_unlock": m.SSHKeyUnlock,
+	} {
+		if v.ValueString() != "" {
+			secrets[key] = v.ValueString()
+		}
+	}
+	return secrets
+}
+
+// writeOnlySecrets returns the write-only secret inputs described by m,
+// which are only set in configuration.
+func (m CredentialScmResourceModel) writeOnlySecrets() map[string]string {
+	secrets := map[string]string{}
+	for key, v := range map[string]types.String{
+		"password":       m.PasswordWO,
+		"ssh_key_data":   m.SSHKeyDataWO,
+		"ssh_key_unlock": m.SSHKeyUnlockWO,
+	} {
+		if v.ValueString() != "" {
+			secrets[key] = v.ValueString()
+		}
+	}
+	return secrets
+}
+
+// clearSecrets sets the secret attributes of m to null.
+func (m *CredentialScmResourceModel) clearSecrets() {
+	m.Password = types.StringNull()
+	m.SSHKeyData = types.StringNull()
+	m.SSHKeyUnlock = types.StringNull()
 }
 
 func (r *CredentialScmResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -57,7 +126,8 @@ func (r *CredentialScmResource) Schema(ctx context.Context, req resource.SchemaR
 				Optional: true,
 			},
 			"organization_id": schema.StringAttribute{
-				Required: true,
+				Optional:            true,
+				MarkdownDescription: organizationDescription,
 			},
 			"username": schema.StringAttribute{
 				Optional: true,
@@ -74,6 +144,36 @@ func (r *CredentialScmResource) Schema(ctx context.Context, req resource.SchemaR
 				Optional:  true,
 				Sensitive: true,
 			},
+			"password_wo": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				MarkdownDescription: writeOnlyDescription("password"),
+			},
+			"ssh_key_data_wo": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				MarkdownDescription: writeOnlyDescription("ssh_key_data"),
+			},
+			"ssh_key_unlock_wo": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				MarkdownDescription: writeOnlyDescription("ssh_key_unlock"),
+			},
+			"secret_version": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: secretVersionDescription,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -90,35 +190,69 @@ func (r *CredentialScmResource) Configure(ctx context.Context, req resource.Conf
 	r.client = c
 }
 
-func (r *CredentialScmResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+func (r *CredentialScmResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
 	var data CredentialScmResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateWriteOnlySecrets(&resp.Diagnostics, map[string][2]types.String{
+		"password":       {data.Password, data.PasswordWO},
+		"ssh_key_data":   {data.SSHKeyData, data.SSHKeyDataWO},
+		"ssh_key_unlock": {data.SSHKeyUnlock, data.SSHKeyUnlockWO},
+	}, data.SecretVersion)
+}
+
+// ModifyPlan rejects creating the credential without an organization.
+func (r *CredentialScmResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	requireOrganizationOnCreate(ctx, req, resp)
+}
+
+func (r *CredentialScmResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data, config CredentialScmResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	orgID, _ := strconv.Atoi(data.OrganizationID.ValueString())
+	credentialType, err := r.client.ManagedCredentialTypeID(ctx, client.NamespaceSourceControl)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create credential: %s", err))
+		return
+	}
 
+	inputs := data.inputs()
+	for key, v := range config.writeOnlySecrets() {
+		inputs[key] = v
+	}
 	cred := &client.Credential{
 		Name:           data.Name.ValueString(),
 		Description:    data.Description.ValueString(),
 		Organization:   orgID,
-		CredentialType: 2, // SCM credential type
-		Inputs: client.CredentialInputs{
-			Username:     data.Username.ValueString(),
-			Password:     data.Password.ValueString(),
-			SSHKeyData:   data.SSHKeyData.ValueString(),
-			SSHKeyUnlock: data.SSHKeyUnlock.ValueString(),
-		},
+		CredentialType: credentialType,
+		Inputs:         inputs,
 	}
 
-	created, err := r.client.CreateCredential(cred)
+	created, err := r.client.Credentials.Create(ctx, cred)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create SCM credential: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to create SCM credential", err, credentialScmAPIFields)
 		return
 	}
 
 	data.ID = types.StringValue(strconv.Itoa(created.ID))
+	resp.Diagnostics.Append(setSecretsRecord(ctx, resp.Private, data.secrets(), config.writeOnlySecrets(), created.Modified)...)
+	resp.Diagnostics.Append(setStoredSecrets(ctx, resp.Private, created.Inputs)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -129,50 +263,86 @@ func (r *CredentialScmResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	cred, err := r.client.GetCredential(id)
+	cred, err := r.client.Credentials.Get(ctx, id)
 	if err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read SCM credential: %s", err))
 		return
 	}
 
 	data.Name = types.StringValue(cred.Name)
-	data.Description = types.StringValue(cred.Description)
-	data.OrganizationID = types.StringValue(strconv.Itoa(cred.Organization))
-	data.Username = types.StringValue(cred.Inputs.Username)
+	data.Description = stringValue(data.Description, cred.Description)
+	data.OrganizationID = idValue(cred.Organization)
+	data.Username = stringValue(data.Username, cred.Inputs.String("username"))
+	resp.Diagnostics.Append(setStoredSecrets(ctx, resp.Private, cred.Inputs)...)
+	if secretsDrifted(ctx, req, resp, cred.Name, data.secrets(), cred.Modified) {
+		// Clearing secret_version also has write-only secrets sent again.
+		data.clearSecrets()
+		data.SecretVersion = types.StringNull()
+	}
+	if justImported(ctx, req, resp) {
+		addUnreadableSecretsWarning(&resp.Diagnostics, map[string]string{
+			"password":       cred.Inputs.String("password"),
+			"ssh_key_data":   cred.Inputs.String("ssh_key_data"),
+			"ssh_key_unlock": cred.Inputs.String("ssh_key_unlock"),
+		})
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *CredentialScmResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data CredentialScmResourceModel
+	var data, state, config CredentialScmResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	orgID, _ := strconv.Atoi(data.OrganizationID.ValueString())
 
-	cred := &client.Credential{
-		ID:             id,
-		Name:           data.Name.ValueString(),
-		Description:    data.Description.ValueString(),
-		Organization:   orgID,
-		CredentialType: 2,
-		Inputs: client.CredentialInputs{
-			Username:     data.Username.ValueString(),
-			Password:     data.Password.ValueString(),
-			SSHKeyData:   data.SSHKeyData.ValueString(),
-			SSHKeyUnlock: data.SSHKeyUnlock.ValueString(),
-		},
+	patch := patchBuilder{}
+	patch.String("name", data.Name, state.Name)
+	patch.String("description", data.Description, state.Description)
+	patch.ID("organization", data.OrganizationID, state.OrganizationID)
+	var writeOnly map[string]string
+	if inputs := data.inputs(); sendInputs(!maps.Equal(inputs, state.inputs()), data.SecretVersion, state.SecretVersion) {
+		writeOnly = config.writeOnlySecrets()
+		for key, v := range writeOnly {
+			inputs[key] = v
+		}
+		resp.Diagnostics.Append(keepStoredSecrets(ctx, req.Private, inputs, state.secrets())...)
+		patch["inputs"] = inputs
 	}
 
-	_, err := r.client.UpdateCredential(cred)
+	updated, err := r.client.Credentials.Patch(ctx, id, patch)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update SCM credential: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to update SCM credential", err, credentialScmAPIFields)
 		return
 	}
+	resp.Diagnostics.Append(setSecretsRecord(ctx, resp.Private, data.secrets(), writeOnly, updated.Modified)...)
+	resp.Diagnostics.Append(setStoredSecrets(ctx, resp.Private, updated.Inputs)...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -183,12 +353,24 @@ func (r *CredentialScmResource) Delete(ctx context.Context, req resource.DeleteR
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	if err := r.client.DeleteCredential(id); err != nil {
+	if err := r.client.Credentials.Delete(ctx, id); err != nil && !client.IsNotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete SCM credential: %s", err))
 	}
 }
 
 func (r *CredentialScmResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	markImported(ctx, resp)
+	importByIDOrName(ctx, r.client, req, resp, []string{"<name>", "<organization>/<name>"}, func(ctx context.Context, names []string) (int, error) {
+		return findCredentialID(ctx, r.client, client.NamespaceSourceControl, "SCM credential", names)
+	})
 }
@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccCredentialScmResource(t *testing.T) {
+	s := newTestServer(t)
+	var id int
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(s, "credentials"),
+		Steps: []resource.TestStep{
+			// Create
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialScmConfig("Git", `
+  description = "Git access"
+  username    = "git"
+  password    = "token-1"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("aap_credential_scm.test", "id"),
+					resource.TestCheckResourceAttr("aap_credential_scm.test", "name", "Git"),
+					resource.TestCheckResourceAttr("aap_credential_scm.test", "username", "git"),
+					testAccCaptureID("aap_credential_scm.test", &id),
+				),
+			},
+			// Import. Secrets are write-only in the API.
+			{
+				ResourceName:            "aap_credential_scm.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts", "password"},
+			},
+			// Import by name
+			{
+				ResourceName:            "aap_credential_scm.test",
+				ImportState:             true,
+				ImportStateId:           "SCM Org/Git",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts", "password"},
+			},
+			// Update in place, including a secret
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialScmConfig("GitHub", `
+  description = "GitHub access"
+  username    = "git"
+  password    = "token-2"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aap_credential_scm.test", "name", "GitHub"),
+					resource.TestCheckResourceAttr("aap_credential_scm.test", "description", "GitHub access"),
+					func(*terraform.State) error {
+						if inputs := s.Object("credentials", id)["inputs"].(map[string]interface{}); inputs["password"] != "token-2" {
+							return fmt.Errorf("expected updated password, got %v", inputs)
+						}
+						return nil
+					},
+				),
+			},
+			// Clear optional attributes
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialScmConfig("GitHub", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("aap_credential_scm.test", "description"),
+					resource.TestCheckNoResourceAttr("aap_credential_scm.test", "username"),
+					resource.TestCheckNoResourceAttr("aap_credential_scm.test", "password"),
+				),
+			},
+			// Deleted outside Terraform
+			{
+				PreConfig:          testAccDeleteOutOfBand(t, s, "credentials", &id),
+				Config:             testAccProviderConfig(s) + testAccCredentialScmConfig("GitHub", ""),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Recreate
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialScmConfig("GitHub", ""),
+				Check:  resource.TestCheckResourceAttr("aap_credential_scm.test", "name", "GitHub"),
+			},
+		},
+	})
+}
+
+func testAccCredentialScmConfig(name, attrs string) string {
+	return testAccOrganizationConfig("SCM Org", "") + fmt.Sprintf(`
+resource "aap_credential_scm" "test" {
+  name            = %q
+  organization_id = aap_organization.test.id
+%s}
+`, name, attrs)
+}
+
+func TestAccCredentialScmResource_noOrganization(t *testing.T) {
+	s := newTestServer(t)
+	var id int
+	config := func(username string) string {
+		return testAccProviderConfig(s) + fmt.Sprintf(`
+resource "aap_credential_scm" "test" {
+  name     = "Personal"
+  username = %q
+}
+`, username)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(s, "credentials"),
+		Steps: []resource.TestStep{
+			// The controller requires an owner for new credentials
+			{
+				Config:      config("git"),
+				ExpectError: regexp.MustCompile(`organization_id must be set when creating a credential`),
+			},
+			// A credential owned by a user can be imported and managed
+			{
+				PreConfig: func() {
+					_, err := s.Create("credentials", map[string]interface{}{
+						"name":            "Personal",
+						"user":            1,
+						"credential_type": 2,
+						"inputs":          map[string]interface{}{"username": "git", "password": "s3cret"},
+					})
+					if err != nil {
+						t.Fatalf("creating credential: %s", err)
+					}
+				},
+				Config:             config("git"),
+				ResourceName:       "aap_credential_scm.test",
+				ImportState:        true,
+				ImportStateId:      "Personal",
+				ImportStatePersist: true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if _, ok := states[0].Attributes["organization_id"]; ok {
+						return fmt.Errorf("expected no organization_id, got %q", states[0].Attributes["organization_id"])
+					}
+					return nil
+				},
+			},
+			{
+				Config:   config("git"),
+				PlanOnly: true,
+			},
+			// Changing another input keeps the password stored on the
+			// controller, which Terraform does not manage
+			{
+				Config: config("git-ops"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCaptureID("aap_credential_scm.test", &id),
+					func(*terraform.State) error {
+						inputs := s.Object("credentials", id)["inputs"].(map[string]interface{})
+						if inputs["username"] != "git-ops" || inputs["password"] != "s3cret" {
+							return fmt.Errorf("expected the password to be kept, got %v", inputs)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,249 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccCredentialResource(t *testing.T) {
+	s := newTestServer(t)
+	var id, machineID int
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(s, "credentials"),
+		Steps: []resource.TestStep{
+			// Create with a custom credential type
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialConfig("API", `
+  description        = "Inventory API"
+  credential_type_id = aap_credential_type.test.id
+  inputs = {
+    url    = "https://api.example.com"
+    verify = "true"
+  }
+  secret_inputs = {
+    token = "token-1"
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("aap_credential.test", "id"),
+					resource.TestCheckResourceAttrPair("aap_credential.test", "credential_type_id", "aap_credential_type.test", "id"),
+					resource.TestCheckResourceAttr("aap_credential.test", "inputs.url", "https://api.example.com"),
+					resource.TestCheckResourceAttr("aap_credential.test", "inputs.verify", "true"),
+					resource.TestCheckResourceAttr("aap_credential.test", "secret_inputs.token", "token-1"),
+					testAccCaptureID("aap_credential.test", &id),
+					func(*terraform.State) error {
+						inputs := s.Object("credentials", id)["inputs"].(map[string]interface{})
+						if inputs["verify"] != true || inputs["token"] != "token-1" {
+							return fmt.Errorf("expected boolean verify and token, got %v", inputs)
+						}
+						return nil
+					},
+				),
+			},
+			// Import. Secrets are write-only in the API.
+			{
+				ResourceName:            "aap_credential.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts", "secret_inputs"},
+			},
+			// Import by name
+			{
+				ResourceName:            "aap_credential.test",
+				ImportState:             true,
+				ImportStateId:           "Credential Org/API",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts", "secret_inputs"},
+			},
+			// Update in place, including a secret
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialConfig("API", `
+  credential_type_id = aap_credential_type.test.id
+  inputs = {
+    url    = "https://api.example.com"
+    verify = "false"
+  }
+  secret_inputs = {
+    token = "token-2"
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("aap_credential.test", "description"),
+					resource.TestCheckResourceAttr("aap_credential.test", "inputs.verify", "false"),
+					func(*terraform.State) error {
+						inputs := s.Object("credentials", id)["inputs"].(map[string]interface{})
+						if inputs["verify"] != false || inputs["token"] != "token-2" {
+							return fmt.Errorf("expected updated inputs, got %v", inputs)
+						}
+						return nil
+					},
+				),
+			},
+			// Changing the type by name replaces the credential
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialConfig("API", `
+  credential_type_name = "Machine"
+  inputs = {
+    username = "deploy"
+  }
+  secret_inputs = {
+    password = "hunter2"
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aap_credential.test", "credential_type_id", "1"),
+					resource.TestCheckResourceAttr("aap_credential.test", "inputs.username", "deploy"),
+					testAccCaptureID("aap_credential.test", &machineID),
+					func(*terraform.State) error {
+						if machineID == id {
+							return fmt.Errorf("expected credential %d to be replaced", id)
+						}
+						return nil
+					},
+				),
+			},
+			// Deleted outside Terraform
+			{
+				PreConfig: testAccDeleteOutOfBand(t, s, "credentials", &machineID),
+				Config: testAccProviderConfig(s) + testAccCredentialConfig("API", `
+  credential_type_name = "Machine"
+  inputs = {
+    username = "deploy"
+  }
+`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccCredentialResource_invalid(t *testing.T) {
+	s := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialConfig("API", `
+  inputs = {
+    url = "https://api.example.com"
+  }
+`),
+				ExpectError: regexp.MustCompile(`Exactly one of credential_type_id and credential_type_name`),
+			},
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialConfig("API", `
+  credential_type_id = aap_credential_type.test.id
+  inputs = {
+    token = "token-1"
+  }
+  secret_inputs = {
+    token = "token-1"
+  }
+`),
+				ExpectError: regexp.MustCompile(`Input "token" is set in both inputs and secret_inputs`),
+			},
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialConfig("API", `
+  credential_type_name = "No Such Type"
+`),
+				ExpectError: regexp.MustCompile(`no credential type "No Such Type" found`),
+			},
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialConfig("API", `
+  credential_type_id = aap_credential_type.test.id
+  inputs = {
+    verify = "1"
+  }
+`),
+				ExpectError: regexp.MustCompile(`Input "verify" of credential type "Inventory API" is a boolean`),
+			},
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialConfig("API", `
+  credential_type_id = aap_credential_type.test.id
+  inputs = {
+    url = "https://api.example.com"
+  }
+`),
+				ExpectError: regexp.MustCompile(`Credential type "Inventory API" requires input "token"`),
+			},
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialConfig("API", `
+  credential_type_id = aap_credential_type.test.id
+  inputs = {
+    url    = "https://api.example.com"
+    verfiy = "true"
+  }
+  secret_inputs = {
+    token = "token-1"
+  }
+`),
+				ExpectError: regexp.MustCompile(`Credential type "Inventory API" has no input "verfiy"`),
+			},
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialConfig("API", `
+  credential_type_id = aap_credential_type.test.id
+  inputs = {
+    url    = "https://api.example.com"
+    region = "ap"
+  }
+  secret_inputs = {
+    token = "token-1"
+  }
+`),
+				ExpectError: regexp.MustCompile(`(?s)Input "region" of credential type "Inventory API" must be one of us, eu,.*"ap"`),
+			},
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialConfig("API", `
+  credential_type_id = aap_credential_type.test.id
+  inputs = {
+    url   = "https://api.example.com"
+    token = "token-1"
+  }
+`),
+				ExpectError: regexp.MustCompile(`Input "token" of credential type "Inventory API" is secret; set it in\s+secret_inputs instead`),
+			},
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialConfig("API", `
+  credential_type_name = "Machine"
+  inputs = {
+    username = "deploy"
+    token    = "token-1"
+  }
+`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Credential type "Machine" has no input "token"`),
+			},
+		},
+	})
+}
+
+func testAccCredentialConfig(name, attrs string) string {
+	return testAccOrganizationConfig("Credential Org", "") + `
+resource "aap_credential_type" "test" {
+  name = "Inventory API"
+  kind = "cloud"
+  inputs = jsonencode({
+    fields = [
+      { id = "url", label = "URL", type = "string" },
+      { id = "token", label = "Token", type = "string", secret = true },
+      { id = "verify", label = "Verify SSL", type = "boolean" },
+      { id = "region", label = "Region", type = "string", choices = ["us", "eu"] },
+    ]
+    required = ["url", "token"]
+  })
+}
+` + fmt.Sprintf(`
+resource "aap_credential" "test" {
+  name            = %q
+  organization_id = aap_organization.test.id
+%s}
+`, name, attrs)
+}
@@ -2,9 +2,11 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -16,6 +18,7 @@ import (
 
 var _ resource.Resource = &CredentialTypeResource{}
 var _ resource.ResourceWithImportState = &CredentialTypeResource{}
+var _ resource.ResourceWithValidateConfig = &CredentialTypeResource{}
 
 func NewCredentialTypeResource() resource.Resource {
 	return &CredentialTypeResource{}
@@ -26,12 +29,22 @@ type CredentialTypeResource struct {
 }
 
 type CredentialTypeResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	Kind        types.String `tfsdk:"kind"`
-	Inputs      types.String `tfsdk:"inputs"`
-	Injectors   types.String `tfsdk:"injectors"`
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	Kind        types.String   `tfsdk:"kind"`
+	Inputs      types.String   `tfsdk:"inputs"`
+	Injectors   types.String   `tfsdk:"injectors"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+// credentialTypeAPIFields maps controller field names to schema attributes for validation errors.
+var credentialTypeAPIFields = map[string]string{
+	"name":        "name",
+	"description": "description",
+	"kind":        "kind",
+	"inputs":      "inputs",
+	"injectors":   "injectors",
 }
 
 func (r *CredentialTypeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -67,6 +80,14 @@ func (r *CredentialTypeResource) Schema(ctx context.Context, req resource.Schema
 				MarkdownDescription: "Injector configuration in JSON format.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -82,6 +103,24 @@ func (r *CredentialTypeResource) Configure(ctx context.Context, req resource.Con
 	r.client = c
 }
 
+// ValidateConfig rejects inputs and injectors that are not valid JSON, which
+// would otherwise only fail when the request body is encoded during apply.
+func (r *CredentialTypeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data CredentialTypeResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for name, v := range map[string]types.String{"inputs": data.Inputs, "injectors": data.Injectors} {
+		if v.IsNull() || v.IsUnknown() || json.Valid([]byte(v.ValueString())) {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(path.Root(name), "Invalid JSON",
+			fmt.Sprintf("%s must be a JSON document, for example produced with jsonencode().", name))
+	}
+}
+
 func (r *CredentialTypeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data CredentialTypeResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -89,17 +128,25 @@ func (r *CredentialTypeResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	ct := &client.CredentialType{
 		Name:        data.Name.ValueString(),
 		Description: data.Description.ValueString(),
 		Kind:        data.Kind.ValueString(),
-		Inputs:      data.Inputs.ValueString(),
-		Injectors:   data.Injectors.ValueString(),
+		Inputs:      jsonBody(data.Inputs),
+		Injectors:   jsonBody(data.Injectors),
 	}
 
-	created, err := r.client.CreateCredentialType(ct)
+	created, err := r.client.CredentialTypes.Create(ctx, ct)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create credential type: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to create credential type", err, credentialTypeAPIFields)
 		return
 	}
 
@@ -114,43 +161,62 @@ func (r *CredentialTypeResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	ct, err := r.client.GetCredentialType(id)
+	ct, err := r.client.CredentialTypes.Get(ctx, id)
 	if err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read credential type: %s", err))
 		return
 	}
 
 	data.Name = types.StringValue(ct.Name)
-	data.Description = types.StringValue(ct.Description)
+	data.Description = stringValue(data.Description, ct.Description)
 	data.Kind = types.StringValue(ct.Kind)
-	data.Inputs = types.StringValue(ct.Inputs)
-	data.Injectors = types.StringValue(ct.Injectors)
+	data.Inputs = jsonValue(data.Inputs, ct.Inputs)
+	data.Injectors = jsonValue(data.Injectors, ct.Injectors)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *CredentialTypeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data CredentialTypeResourceModel
+	var data, state CredentialTypeResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	id, _ := strconv.Atoi(data.ID.ValueString())
 
-	ct := &client.CredentialType{
-		ID:          id,
-		Name:        data.Name.ValueString(),
-		Description: data.Description.ValueString(),
-		Kind:        data.Kind.ValueString(),
-		Inputs:      data.Inputs.ValueString(),
-		Injectors:   data.Injectors.ValueString(),
-	}
+	patch := patchBuilder{}
+	patch.String("name", data.Name, state.Name)
+	patch.String("description", data.Description, state.Description)
+	patch.String("kind", data.Kind, state.Kind)
+	patch.JSON("inputs", data.Inputs, state.Inputs)
+	patch.JSON("injectors", data.Injectors, state.Injectors)
 
-	_, err := r.client.UpdateCredentialType(ct)
+	_, err := r.client.CredentialTypes.Patch(ctx, id, patch)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update credential type: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to update credential type", err, credentialTypeAPIFields)
 		return
 	}
 
@@ -163,12 +229,25 @@ func (r *CredentialTypeResource) Delete(ctx context.Context, req resource.Delete
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	if err := r.client.DeleteCredentialType(id); err != nil {
+	if err := r.client.CredentialTypes.Delete(ctx, id); err != nil && !client.IsNotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete credential type: %s", err))
 	}
 }
 
 func (r *CredentialTypeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importByIDOrName(ctx, r.client, req, resp, []string{"<name>"}, func(ctx context.Context, names []string) (int, error) {
+		return findID(r.client.CredentialTypes.List(ctx, client.ListOptions{Name: names[0]}),
+			func(ct *client.CredentialType) int { return ct.ID },
+			fmt.Sprintf("credential type %q", names[0]))
+	})
 }
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCredentialTypeResource(t *testing.T) {
+	s := newTestServer(t)
+	var id int
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Invalid JSON is rejected when planning
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialTypeConfig("API Token", `
+  injectors = "{env: {API_TOKEN: '{{ api_token }}'}}"
+`),
+				ExpectError: regexp.MustCompile(`injectors must be a JSON document`),
+			},
+			// Create
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialTypeConfig("API Token", `
+  description = "Token for the inventory API"
+  inputs = jsonencode({
+    fields = [
+      { id = "api_token", label = "API Token", type = "string", secret = true },
+    ]
+    required = ["api_token"]
+  })
+  injectors = jsonencode({
+    env = { API_TOKEN = "{{ api_token }}" }
+  })
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("aap_credential_type.test", "id"),
+					resource.TestCheckResourceAttr("aap_credential_type.test", "name", "API Token"),
+					resource.TestCheckResourceAttr("aap_credential_type.test", "kind", "cloud"),
+					resource.TestCheckResourceAttr("aap_credential_type.test", "injectors", `{"env":{"API_TOKEN":"{{ api_token }}"}}`),
+					testAccCaptureID("aap_credential_type.test", &id),
+				),
+			},
+			// Import
+			{
+				ResourceName:            "aap_credential_type.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Import by name
+			{
+				ResourceName:            "aap_credential_type.test",
+				ImportState:             true,
+				ImportStateId:           "API Token",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Update in place
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialTypeConfig("Inventory API Token", `
+  description = "Token for the inventory API"
+  inputs = jsonencode({
+    fields = [
+      { id = "api_token", label = "API Token", type = "string", secret = true },
+      { id = "api_url", label = "API URL", type = "string" },
+    ]
+    required = ["api_token"]
+  })
+  injectors = jsonencode({
+    env = { API_TOKEN = "{{ api_token }}", API_URL = "{{ api_url }}" }
+  })
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aap_credential_type.test", "name", "Inventory API Token"),
+					resource.TestCheckResourceAttr("aap_credential_type.test", "injectors", `{"env":{"API_TOKEN":"{{ api_token }}","API_URL":"{{ api_url }}"}}`),
+				),
+			},
+			// Clear optional attributes
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialTypeConfig("Inventory API Token", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("aap_credential_type.test", "description"),
+					resource.TestCheckNoResourceAttr("aap_credential_type.test", "inputs"),
+					resource.TestCheckNoResourceAttr("aap_credential_type.test", "injectors"),
+				),
+			},
+			// Deleted outside Terraform
+			{
+				PreConfig:          testAccDeleteOutOfBand(t, s, "credential_types", &id),
+				Config:             testAccProviderConfig(s) + testAccCredentialTypeConfig("Inventory API Token", ""),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Recreate
+			{
+				Config: testAccProviderConfig(s) + testAccCredentialTypeConfig("Inventory API Token", ""),
+				Check:  resource.TestCheckResourceAttr("aap_credential_type.test", "name", "Inventory API Token"),
+			},
+		},
+	})
+}
+
+func testAccCredentialTypeConfig(name, attrs string) string {
+	return fmt.Sprintf(`
+resource "aap_credential_type" "test" {
+  name = %q
+  kind = "cloud"
+%s}
+`, name, attrs)
+}
@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"strconv"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -26,13 +26,24 @@ type InventoryResource struct {
 }
 
 type InventoryResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	Name           types.String `tfsdk:"name"`
-	Description    types.String `tfsdk:"description"`
-	OrganizationID types.String `tfsdk:"organization_id"`
-	Kind           types.String `tfsdk:"kind"`
-	HostFilter     types.String `tfsdk:"host_filter"`
-	Variables      types.String `tfsdk:"variables"`
+	ID             types.String   `tfsdk:"id"`
+	Name           types.String   `tfsdk:"name"`
+	Description    types.String   `tfsdk:"description"`
+	OrganizationID types.String   `tfsdk:"organization_id"`
+	Kind           types.String   `tfsdk:"kind"`
+	HostFilter     types.String   `tfsdk:"host_filter"`
+	Variables      types.String   `tfsdk:"variables"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// inventoryAPIFields maps controller field names to schema attributes for validation errors.
+var inventoryAPIFields = map[string]string{
+	"name":         "name",
+	"description":  "description",
+	"organization": "organization_id",
+	"kind":         "kind",
+	"host_filter":  "host_filter",
+	"variables":    "variables",
 }
 
 func (r *InventoryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -79,6 +90,14 @@ func (r *InventoryResource) Schema(ctx context.Context, req resource.SchemaReque
 				MarkdownDescription: "Inventory variables in JSON or YAML format.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -105,6 +124,14 @@ func (r *InventoryResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	orgID, _ := strconv.Atoi(data.OrganizationID.ValueString())
 
 	inv := &client.Inventory{
@@ -125,19 +152,19 @@ func (r *InventoryResource) Create(ctx context.Context, req resource.CreateReque
 		inv.Variables = data.Variables.ValueString()
 	}
 
-	createdInv, err := r.client.CreateInventory(inv)
+	createdInv, err := r.client.Inventories.Create(ctx, inv)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create inventory: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to create inventory", err, inventoryAPIFields)
 		return
 	}
 
 	data.ID = types.StringValue(strconv.Itoa(createdInv.ID))
 	data.Name = types.StringValue(createdInv.Name)
-	data.Description = types.StringValue(createdInv.Description)
+	data.Description = stringValue(data.Description, createdInv.Description)
 	data.OrganizationID = types.StringValue(strconv.Itoa(createdInv.Organization))
 	data.Kind = types.StringValue(createdInv.Kind)
-	data.HostFilter = types.StringValue(createdInv.HostFilter)
-	data.Variables = types.StringValue(createdInv.Variables)
+	data.HostFilter = stringValue(data.HostFilter, createdInv.HostFilter)
+	data.Variables = stringValue(data.Variables, createdInv.Variables)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -150,70 +177,79 @@ func (r *InventoryResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	id, err := strconv.Atoi(data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid ID", err.Error())
 		return
 	}
 
-	inv, err := r.client.GetInventory(id)
+	inv, err := r.client.Inventories.Get(ctx, id)
 	if err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read inventory: %s", err))
 		return
 	}
 
 	data.Name = types.StringValue(inv.Name)
-	data.Description = types.StringValue(inv.Description)
+	data.Description = stringValue(data.Description, inv.Description)
 	data.OrganizationID = types.StringValue(strconv.Itoa(inv.Organization))
 	data.Kind = types.StringValue(inv.Kind)
-	data.HostFilter = types.StringValue(inv.HostFilter)
-	data.Variables = types.StringValue(inv.Variables)
+	data.HostFilter = stringValue(data.HostFilter, inv.HostFilter)
+	data.Variables = stringValue(data.Variables, inv.Variables)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *InventoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data InventoryResourceModel
+	var data, state InventoryResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	id, _ := strconv.Atoi(data.ID.ValueString())
-	orgID, _ := strconv.Atoi(data.OrganizationID.ValueString())
-
-	inv := &client.Inventory{
-		ID:           id,
-		Name:         data.Name.ValueString(),
-		Organization: orgID,
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
-	if !data.Description.IsNull() {
-		inv.Description = data.Description.ValueString()
-	}
-	if !data.Kind.IsNull() {
-		inv.Kind = data.Kind.ValueString()
-	}
-	if !data.HostFilter.IsNull() {
-		inv.HostFilter = data.HostFilter.ValueString()
-	}
-	if !data.Variables.IsNull() {
-		inv.Variables = data.Variables.ValueString()
-	}
+	id, _ := strconv.Atoi(data.ID.ValueString())
 
-	updatedInv, err := r.client.UpdateInventory(inv)
+	patch := patchBuilder{}
+	patch.String("name", data.Name, state.Name)
+	patch.String("description", data.Description, state.Description)
+	patch.ID("organization", data.OrganizationID, state.OrganizationID)
+	patch.String("kind", data.Kind, state.Kind)
+	patch.String("host_filter", data.HostFilter, state.HostFilter)
+	patch.String("variables", data.Variables, state.Variables)
+
+	updatedInv, err := r.client.Inventories.Patch(ctx, id, patch)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update inventory: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to update inventory", err, inventoryAPIFields)
 		return
 	}
 
 	data.Name = types.StringValue(updatedInv.Name)
-	data.Description = types.StringValue(updatedInv.Description)
+	data.Description = stringValue(data.Description, updatedInv.Description)
 	data.OrganizationID = types.StringValue(strconv.Itoa(updatedInv.Organization))
 	data.Kind = types.StringValue(updatedInv.Kind)
-	data.HostFilter = types.StringValue(updatedInv.HostFilter)
-	data.Variables = types.StringValue(updatedInv.Variables)
+	data.HostFilter = stringValue(data.HostFilter, updatedInv.HostFilter)
+	data.Variables = stringValue(data.Variables, updatedInv.Variables)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -224,12 +260,25 @@ func (r *InventoryResource) Delete(ctx context.Context, req resource.DeleteReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	if err := r.client.DeleteInventory(id); err != nil {
+	if err := r.client.Inventories.Delete(ctx, id); err != nil && !client.IsNotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete inventory: %s", err))
 	}
 }
 
 func (r *InventoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importByIDOrName(ctx, r.client, req, resp, []string{"<organization>/<name>"}, func(ctx context.Context, names []string) (int, error) {
+		return findID(r.client.Inventories.List(ctx, client.ListOptions{OrganizationName: names[0], Name: names[1]}),
+			func(i *client.Inventory) int { return i.ID },
+			fmt.Sprintf("inventory %q in organization %q", names[1], names[0]))
+	})
 }
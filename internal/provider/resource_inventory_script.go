@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"strconv"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -16,6 +16,7 @@ import (
 
 var _ resource.Resource = &InventoryScriptResource{}
 var _ resource.ResourceWithImportState = &InventoryScriptResource{}
+var _ resource.ResourceWithModifyPlan = &InventoryScriptResource{}
 
 func NewInventoryScriptResource() resource.Resource {
 	return &InventoryScriptResource{}
@@ -26,11 +27,20 @@ type InventoryScriptResource struct {
 }
 
 type InventoryScriptResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	Name           types.String `tfsdk:"name"`
-	Description    types.String `tfsdk:"description"`
-	OrganizationID types.String `tfsdk:"organization_id"`
-	Script         types.String `tfsdk:"script"`
+	ID             types.String   `tfsdk:"id"`
+	Name           types.String   `tfsdk:"name"`
+	Description    types.String   `tfsdk:"description"`
+	OrganizationID types.String   `tfsdk:"organization_id"`
+	Script         types.String   `tfsdk:"script"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// inventoryScriptAPIFields maps controller field names to schema attributes for validation errors.
+var inventoryScriptAPIFields = map[string]string{
+	"name":         "name",
+	"description":  "description",
+	"organization": "organization_id",
+	"script":       "script",
 }
 
 func (r *InventoryScriptResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -64,6 +74,14 @@ func (r *InventoryScriptResource) Schema(ctx context.Context, req resource.Schem
 				MarkdownDescription: "The inventory script content (Python or shell script).",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -79,6 +97,17 @@ func (r *InventoryScriptResource) Configure(ctx context.Context, req resource.Co
 	r.client = c
 }
 
+// ModifyPlan rejects creating or updating inventory scripts on servers that
+// no longer provide them, instead of failing with a 404 during apply.
+func (r *InventoryScriptResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	if err := r.client.Supports(client.FeatureInventoryScripts); err != nil {
+		resp.Diagnostics.AddError("Unsupported Resource", fmt.Sprintf("%s. Use an aap_inventory_source with a project-based source instead.", err))
+	}
+}
+
 func (r *InventoryScriptResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data InventoryScriptResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -86,6 +115,14 @@ func (r *InventoryScriptResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	orgID, _ := strconv.Atoi(data.OrganizationID.ValueString())
 
 	is := &client.InventoryScript{
@@ -95,9 +132,9 @@ func (r *InventoryScriptResource) Create(ctx context.Context, req resource.Creat
 		Script:       data.Script.ValueString(),
 	}
 
-	created, err := r.client.CreateInventoryScript(is)
+	created, err := r.client.InventoryScripts.Create(ctx, is)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create inventory script: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to create inventory script", err, inventoryScriptAPIFields)
 		return
 	}
 
@@ -112,15 +149,27 @@ func (r *InventoryScriptResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	is, err := r.client.GetInventoryScript(id)
+	is, err := r.client.InventoryScripts.Get(ctx, id)
 	if err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read inventory script: %s", err))
 		return
 	}
 
 	data.Name = types.StringValue(is.Name)
-	data.Description = types.StringValue(is.Description)
+	data.Description = stringValue(data.Description, is.Description)
 	data.OrganizationID = types.StringValue(strconv.Itoa(is.Organization))
 	data.Script = types.StringValue(is.Script)
 
@@ -128,26 +177,32 @@ func (r *InventoryScriptResource) Read(ctx context.Context, req resource.ReadReq
 }
 
 func (r *InventoryScriptResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data InventoryScriptResourceModel
+	var data, state InventoryScriptResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	orgID, _ := strconv.Atoi(data.OrganizationID.ValueString())
 
-	is := &client.InventoryScript{
-		ID:           id,
-		Name:         data.Name.ValueString(),
-		Description:  data.Description.ValueString(),
-		Organization: orgID,
-		Script:       data.Script.ValueString(),
-	}
+	patch := patchBuilder{}
+	patch.String("name", data.Name, state.Name)
+	patch.String("description", data.Description, state.Description)
+	patch.ID("organization", data.OrganizationID, state.OrganizationID)
+	patch.String("script", data.Script, state.Script)
 
-	_, err := r.client.UpdateInventoryScript(is)
+	_, err := r.client.InventoryScripts.Patch(ctx, id, patch)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update inventory script: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to update inventory script", err, inventoryScriptAPIFields)
 		return
 	}
 
@@ -160,12 +215,25 @@ func (r *InventoryScriptResource) Delete(ctx context.Context, req resource.Delet
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	if err := r.client.DeleteInventoryScript(id); err != nil {
+	if err := r.client.InventoryScripts.Delete(ctx, id); err != nil && !client.IsNotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete inventory script: %s", err))
 	}
 }
 
 func (r *InventoryScriptResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importByIDOrName(ctx, r.client, req, resp, []string{"<organization>/<name>"}, func(ctx context.Context, names []string) (int, error) {
+		return findID(r.client.InventoryScripts.List(ctx, client.ListOptions{OrganizationName: names[0], Name: names[1]}),
+			func(s *client.InventoryScript) int { return s.ID },
+			fmt.Sprintf("inventory script %q in organization %q", names[1], names[0]))
+	})
 }
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccInventoryScriptResource(t *testing.T) {
+	s := newTestServer(t)
+	s.Version = "3.8.6"
+	var id int
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(s, "inventory_scripts"),
+		Steps: []resource.TestStep{
+			// Create
+			{
+				Config: testAccProviderConfig(s) + testAccInventoryScriptConfig("Static hosts", `
+  description = "Prints a static inventory"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("aap_inventory_script.test", "id"),
+					resource.TestCheckResourceAttr("aap_inventory_script.test", "name", "Static hosts"),
+					resource.TestCheckResourceAttr("aap_inventory_script.test", "description", "Prints a static inventory"),
+					resource.TestCheckResourceAttr("aap_inventory_script.test", "script", "#!/bin/sh\necho '{}'\n"),
+					testAccCaptureID("aap_inventory_script.test", &id),
+				),
+			},
+			// Import
+			{
+				ResourceName:            "aap_inventory_script.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Import by name
+			{
+				ResourceName:            "aap_inventory_script.test",
+				ImportState:             true,
+				ImportStateId:           "Script Org/Static hosts",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Update in place
+			{
+				Config: testAccProviderConfig(s) + testAccInventoryScriptConfig("Static inventory", `
+  description = "Prints an empty inventory"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aap_inventory_script.test", "name", "Static inventory"),
+					resource.TestCheckResourceAttr("aap_inventory_script.test", "description", "Prints an empty inventory"),
+				),
+			},
+			// Clear optional attributes
+			{
+				Config: testAccProviderConfig(s) + testAccInventoryScriptConfig("Static inventory", ""),
+				Check:  resource.TestCheckNoResourceAttr("aap_inventory_script.test", "description"),
+			},
+			// Deleted outside Terraform
+			{
+				PreConfig:          testAccDeleteOutOfBand(t, s, "inventory_scripts", &id),
+				Config:             testAccProviderConfig(s) + testAccInventoryScriptConfig("Static inventory", ""),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Recreate
+			{
+				Config: testAccProviderConfig(s) + testAccInventoryScriptConfig("Static inventory", ""),
+				Check:  resource.TestCheckResourceAttr("aap_inventory_script.test", "name", "Static inventory"),
+			},
+		},
+	})
+}
+
+func TestAccInventoryScriptResource_unsupportedVersion(t *testing.T) {
+	s := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccProviderConfig(s) + testAccInventoryScriptConfig("Static hosts", ""),
+				ExpectError: regexp.MustCompile(`not available on automation controller 4\.6\.2`),
+			},
+		},
+	})
+}
+
+func testAccInventoryScriptConfig(name, attrs string) string {
+	return testAccOrganizationConfig("Script Org", "") + fmt.Sprintf(`
+resource "aap_inventory_script" "test" {
+  name            = %q
+  organization_id = aap_organization.test.id
+  script          = "#!/bin/sh\necho '{}'\n"
+%s}
+`, name, attrs)
+}
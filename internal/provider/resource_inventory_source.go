@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"strconv"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -26,19 +26,36 @@ type InventorySourceResource struct {
 }
 
 type InventorySourceResourceModel struct {
-	ID                 types.String `tfsdk:"id"`
-	Name               types.String `tfsdk:"name"`
-	Description        types.String `tfsdk:"description"`
-	InventoryID        types.String `tfsdk:"inventory_id"`
-	Source             types.String `tfsdk:"source"`
-	SourcePath         types.String `tfsdk:"source_path"`
-	SourceVars         types.String `tfsdk:"source_vars"`
-	CredentialID       types.String `tfsdk:"credential_id"`
-	SourceProjectID    types.String `tfsdk:"source_project_id"`
-	UpdateOnLaunch     types.Bool   `tfsdk:"update_on_launch"`
-	UpdateCacheTimeout types.Int64  `tfsdk:"update_cache_timeout"`
-	Overwrite          types.Bool   `tfsdk:"overwrite"`
-	OverwriteVars      types.Bool   `tfsdk:"overwrite_vars"`
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	Description        types.String   `tfsdk:"description"`
+	InventoryID        types.String   `tfsdk:"inventory_id"`
+	Source             types.String   `tfsdk:"source"`
+	SourcePath         types.String   `tfsdk:"source_path"`
+	SourceVars         types.String   `tfsdk:"source_vars"`
+	CredentialID       types.String   `tfsdk:"credential_id"`
+	SourceProjectID    types.String   `tfsdk:"source_project_id"`
+	UpdateOnLaunch     types.Bool     `tfsdk:"update_on_launch"`
+	UpdateCacheTimeout types.Int64    `tfsdk:"update_cache_timeout"`
+	Overwrite          types.Bool     `tfsdk:"overwrite"`
+	OverwriteVars      types.Bool     `tfsdk:"overwrite_vars"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// inventorySourceAPIFields maps controller field names to schema attributes for validation errors.
+var inventorySourceAPIFields = map[string]string{
+	"name":                 "name",
+	"description":          "description",
+	"inventory":            "inventory_id",
+	"source":               "source",
+	"source_path":          "source_path",
+	"source_vars":          "source_vars",
+	"credential":           "credential_id",
+	"source_project":       "source_project_id",
+	"update_on_launch":     "update_on_launch",
+	"update_cache_timeout": "update_cache_timeout",
+	"overwrite":            "overwrite",
+	"overwrite_vars":       "overwrite_vars",
 }
 
 func (r *InventorySourceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -96,6 +113,14 @@ func (r *InventorySourceResource) Schema(ctx context.Context, req resource.Schem
 				Optional: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -118,6 +143,14 @@ func (r *InventorySourceResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	invID, _ := strconv.Atoi(data.InventoryID.ValueString())
 	credID, _ := strconv.Atoi(data.CredentialID.ValueString())
 	projID, _ := strconv.Atoi(data.SourceProjectID.ValueString())
@@ -153,9 +186,9 @@ func (r *InventorySourceResource) Create(ctx context.Context, req resource.Creat
 		is.OverwriteVars = data.OverwriteVars.ValueBool()
 	}
 
-	created, err := r.client.CreateInventorySource(is)
+	created, err := r.client.InventorySources.Create(ctx, is)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create inventory source: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to create inventory source", err, inventorySourceAPIFields)
 		return
 	}
 
@@ -170,80 +203,76 @@ func (r *InventorySourceResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	is, err := r.client.GetInventorySource(id)
+	is, err := r.client.InventorySources.Get(ctx, id)
 	if err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read inventory source: %s", err))
 		return
 	}
 
 	data.Name = types.StringValue(is.Name)
-	data.Description = types.StringValue(is.Description)
+	data.Description = stringValue(data.Description, is.Description)
 	data.InventoryID = types.StringValue(strconv.Itoa(is.Inventory))
 	data.Source = types.StringValue(is.Source)
-	data.SourcePath = types.StringValue(is.SourcePath)
-	data.SourceVars = types.StringValue(is.SourceVars)
-	if is.Credential > 0 {
-		data.CredentialID = types.StringValue(strconv.Itoa(is.Credential))
-	}
-	if is.SourceProject > 0 {
-		data.SourceProjectID = types.StringValue(strconv.Itoa(is.SourceProject))
-	}
-	data.UpdateOnLaunch = types.BoolValue(is.UpdateOnLaunch)
-	data.UpdateCacheTimeout = types.Int64Value(int64(is.UpdateCacheTimeout))
-	data.Overwrite = types.BoolValue(is.Overwrite)
-	data.OverwriteVars = types.BoolValue(is.OverwriteVars)
+	data.SourcePath = stringValue(data.SourcePath, is.SourcePath)
+	data.SourceVars = stringValue(data.SourceVars, is.SourceVars)
+	data.CredentialID = idValue(is.Credential)
+	data.SourceProjectID = idValue(is.SourceProject)
+	data.UpdateOnLaunch = boolValue(data.UpdateOnLaunch, is.UpdateOnLaunch)
+	data.UpdateCacheTimeout = int64Value(data.UpdateCacheTimeout, is.UpdateCacheTimeout)
+	data.Overwrite = boolValue(data.Overwrite, is.Overwrite)
+	data.OverwriteVars = boolValue(data.OverwriteVars, is.OverwriteVars)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *InventorySourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data InventorySourceResourceModel
+	var data, state InventorySourceResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	invID, _ := strconv.Atoi(data.InventoryID.ValueString())
-	credID, _ := strconv.Atoi(data.CredentialID.ValueString())
-	projID, _ := strconv.Atoi(data.SourceProjectID.ValueString())
 
-	is := &client.InventorySource{
-		ID:          id,
-		Name:        data.Name.ValueString(),
-		Description: data.Description.ValueString(),
-		Inventory:   invID,
-		Source:      data.Source.ValueString(),
-	}
-	if !data.SourcePath.IsNull() {
-		is.SourcePath = data.SourcePath.ValueString()
-	}
-	if !data.SourceVars.IsNull() {
-		is.SourceVars = data.SourceVars.ValueString()
-	}
-	if credID > 0 {
-		is.Credential = credID
-	}
-	if projID > 0 {
-		is.SourceProject = projID
-	}
-	if !data.UpdateOnLaunch.IsNull() {
-		is.UpdateOnLaunch = data.UpdateOnLaunch.ValueBool()
-	}
-	if !data.UpdateCacheTimeout.IsNull() {
-		is.UpdateCacheTimeout = int(data.UpdateCacheTimeout.ValueInt64())
-	}
-	if !data.Overwrite.IsNull() {
-		is.Overwrite = data.Overwrite.ValueBool()
-	}
-	if !data.OverwriteVars.IsNull() {
-		is.OverwriteVars = data.OverwriteVars.ValueBool()
-	}
+	patch := patchBuilder{}
+	patch.String("name", data.Name, state.Name)
+	patch.String("description", data.Description, state.Description)
+	patch.ID("inventory", data.InventoryID, state.InventoryID)
+	patch.String("source", data.Source, state.Source)
+	patch.String("source_path", data.SourcePath, state.SourcePath)
+	patch.String("source_vars", data.SourceVars, state.SourceVars)
+	patch.ID("credential", data.CredentialID, state.CredentialID)
+	patch.ID("source_project", data.SourceProjectID, state.SourceProjectID)
+	patch.Bool("update_on_launch", data.UpdateOnLaunch, state.UpdateOnLaunch)
+	patch.Int64("update_cache_timeout", data.UpdateCacheTimeout, state.UpdateCacheTimeout)
+	patch.Bool("overwrite", data.Overwrite, state.Overwrite)
+	patch.Bool("overwrite_vars", data.OverwriteVars, state.OverwriteVars)
 
-	_, err := r.client.UpdateInventorySource(is)
+	_, err := r.client.InventorySources.Patch(ctx, id, patch)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update inventory source: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to update inventory source", err, inventorySourceAPIFields)
 		return
 	}
 
@@ -256,12 +285,36 @@ func (r *InventorySourceResource) Delete(ctx context.Context, req resource.Delet
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	if err := r.client.DeleteInventorySource(id); err != nil {
+	if err := r.client.InventorySources.Delete(ctx, id); err != nil && !client.IsNotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete inventory source: %s", err))
 	}
 }
 
 func (r *InventorySourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importByIDOrName(ctx, r.client, req, resp, []string{"<inventory>/<name>", "<organization>/<inventory>/<name>"}, func(ctx context.Context, names []string) (int, error) {
+		opts := client.ListOptions{Name: names[len(names)-2]}
+		what := fmt.Sprintf("inventory %q", opts.Name)
+		if len(names) == 3 {
+			opts.OrganizationName = names[0]
+			what += fmt.Sprintf(" in organization %q", names[0])
+		}
+		inventory, err := findID(r.client.Inventories.List(ctx, opts), func(i *client.Inventory) int { return i.ID }, what)
+		if err != nil {
+			return 0, err
+		}
+		name := names[len(names)-1]
+		return findID(r.client.InventorySources.List(ctx, client.ListOptions{Name: name, Filters: map[string]string{"inventory": strconv.Itoa(inventory)}}),
+			func(s *client.InventorySource) int { return s.ID },
+			fmt.Sprintf("inventory source %q in %s", name, what))
+	})
 }
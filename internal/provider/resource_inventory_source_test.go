@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccInventorySourceResource(t *testing.T) {
+	s := newTestServer(t)
+	var id int
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(s, "inventory_sources"),
+		Steps: []resource.TestStep{
+			// Create
+			{
+				Config: testAccProviderConfig(s) + testAccInventorySourceConfig("Hosts file", `
+  description          = "Hosts from the playbook repository"
+  source_path          = "inventory/hosts.yml"
+  source_vars          = "---\nplugin: yaml"
+  update_on_launch     = true
+  update_cache_timeout = 60
+  overwrite            = true
+  overwrite_vars       = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("aap_inventory_source.test", "id"),
+					resource.TestCheckResourceAttr("aap_inventory_source.test", "name", "Hosts file"),
+					resource.TestCheckResourceAttr("aap_inventory_source.test", "source", "scm"),
+					resource.TestCheckResourceAttrPair("aap_inventory_source.test", "inventory_id", "aap_inventory.test", "id"),
+					resource.TestCheckResourceAttrPair("aap_inventory_source.test", "source_project_id", "aap_project.test", "id"),
+					resource.TestCheckResourceAttr("aap_inventory_source.test", "source_path", "inventory/hosts.yml"),
+					resource.TestCheckResourceAttr("aap_inventory_source.test", "update_cache_timeout", "60"),
+					testAccCaptureID("aap_inventory_source.test", &id),
+				),
+			},
+			// Import
+			{
+				ResourceName:            "aap_inventory_source.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Import by name
+			{
+				ResourceName:            "aap_inventory_source.test",
+				ImportState:             true,
+				ImportStateId:           "Inventory Org/Source Inventory/Hosts file",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			{
+				ResourceName:            "aap_inventory_source.test",
+				ImportState:             true,
+				ImportStateId:           "Source Inventory/Hosts file",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Update in place
+			{
+				Config: testAccProviderConfig(s) + testAccInventorySourceConfig("Repository hosts", `
+  description          = "Hosts from the playbook repository"
+  source_path          = "inventory/prod.yml"
+  source_vars          = "---\nplugin: yaml"
+  update_on_launch     = false
+  update_cache_timeout = 0
+  overwrite            = true
+  overwrite_vars       = false
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aap_inventory_source.test", "name", "Repository hosts"),
+					resource.TestCheckResourceAttr("aap_inventory_source.test", "source_path", "inventory/prod.yml"),
+					resource.TestCheckResourceAttr("aap_inventory_source.test", "update_on_launch", "false"),
+					resource.TestCheckResourceAttr("aap_inventory_source.test", "update_cache_timeout", "0"),
+				),
+			},
+			// Clear optional attributes
+			{
+				Config: testAccProviderConfig(s) + testAccInventorySourceConfig("Repository hosts", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("aap_inventory_source.test", "description"),
+					resource.TestCheckNoResourceAttr("aap_inventory_source.test", "source_path"),
+					resource.TestCheckNoResourceAttr("aap_inventory_source.test", "overwrite"),
+				),
+			},
+			// Deleted outside Terraform
+			{
+				PreConfig:          testAccDeleteOutOfBand(t, s, "inventory_sources", &id),
+				Config:             testAccProviderConfig(s) + testAccInventorySourceConfig("Repository hosts", ""),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Recreate
+			{
+				Config: testAccProviderConfig(s) + testAccInventorySourceConfig("Repository hosts", ""),
+				Check:  resource.TestCheckResourceAttr("aap_inventory_source.test", "name", "Repository hosts"),
+			},
+		},
+	})
+}
+
+func testAccInventorySourceConfig(name, attrs string) string {
+	return testAccInventoryConfig("Source Inventory", "") + fmt.Sprintf(`
+resource "aap_project" "test" {
+  name            = "Inventory Project"
+  organization_id = aap_organization.test.id
+  scm_type        = "git"
+  scm_url         = "https://git.example.com/inventory.git"
+}
+
+resource "aap_inventory_source" "test" {
+  name              = %q
+  inventory_id      = aap_inventory.test.id
+  source            = "scm"
+  source_project_id = aap_project.test.id
+%s}
+`, name, attrs)
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccInventoryResource(t *testing.T) {
+	s := newTestServer(t)
+	var id int
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(s, "inventories"),
+		Steps: []resource.TestStep{
+			// Create
+			{
+				Config: testAccProviderConfig(s) + testAccInventoryConfig("Production", `
+  description = "Production hosts"
+  variables   = "---\nntp_server: ntp.example.com"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("aap_inventory.test", "id"),
+					resource.TestCheckResourceAttr("aap_inventory.test", "name", "Production"),
+					resource.TestCheckResourceAttr("aap_inventory.test", "description", "Production hosts"),
+					resource.TestCheckResourceAttrPair("aap_inventory.test", "organization_id", "aap_organization.test", "id"),
+					resource.TestCheckResourceAttr("aap_inventory.test", "kind", ""),
+					resource.TestCheckResourceAttr("aap_inventory.test", "variables", "---\nntp_server: ntp.example.com"),
+					testAccCaptureID("aap_inventory.test", &id),
+				),
+			},
+			// Import
+			{
+				ResourceName:            "aap_inventory.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Import by name
+			{
+				ResourceName:            "aap_inventory.test",
+				ImportState:             true,
+				ImportStateId:           "Inventory Org/Production",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Update in place
+			{
+				Config: testAccProviderConfig(s) + testAccInventoryConfig("Production EU", `
+  description = "EU production hosts"
+  variables   = "---\nntp_server: ntp.eu.example.com"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aap_inventory.test", "name", "Production EU"),
+					resource.TestCheckResourceAttr("aap_inventory.test", "description", "EU production hosts"),
+					resource.TestCheckResourceAttr("aap_inventory.test", "variables", "---\nntp_server: ntp.eu.example.com"),
+				),
+			},
+			// Clear optional attributes
+			{
+				Config: testAccProviderConfig(s) + testAccInventoryConfig("Production EU", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("aap_inventory.test", "description"),
+					resource.TestCheckNoResourceAttr("aap_inventory.test", "variables"),
+				),
+			},
+			// Deleted outside Terraform
+			{
+				PreConfig:          testAccDeleteOutOfBand(t, s, "inventories", &id),
+				Config:             testAccProviderConfig(s) + testAccInventoryConfig("Production EU", ""),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Recreate
+			{
+				Config: testAccProviderConfig(s) + testAccInventoryConfig("Production EU", ""),
+				Check:  resource.TestCheckResourceAttr("aap_inventory.test", "name", "Production EU"),
+			},
+		},
+	})
+}
+
+func TestAccInventoryResource_smart(t *testing.T) {
+	s := newTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(s) + testAccInventoryConfig("Web servers", `
+  kind        = "smart"
+  host_filter = "name__icontains=web"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aap_inventory.test", "kind", "smart"),
+					resource.TestCheckResourceAttr("aap_inventory.test", "host_filter", "name__icontains=web"),
+				),
+			},
+		},
+	})
+}
+
+func testAccInventoryConfig(name, attrs string) string {
+	return testAccOrganizationConfig("Inventory Org", "") + fmt.Sprintf(`
+resource "aap_inventory" "test" {
+  name            = %q
+  organization_id = aap_organization.test.id
+%s}
+`, name, attrs)
+}
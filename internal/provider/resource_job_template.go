@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"strconv"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -26,17 +26,32 @@ type JobTemplateResource struct {
 }
 
 type JobTemplateResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	JobType     types.String `tfsdk:"job_type"`
-	InventoryID types.String `tfsdk:"inventory_id"`
-	ProjectID   types.String `tfsdk:"project_id"`
-	Playbook    types.String `tfsdk:"playbook"`
-	Forks       types.Int64  `tfsdk:"forks"`
-	Limit       types.String `tfsdk:"limit"`
-	Verbosity   types.Int64  `tfsdk:"verbosity"`
-	ExtraVars   types.String `tfsdk:"extra_vars"`
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	JobType     types.String   `tfsdk:"job_type"`
+	InventoryID types.String   `tfsdk:"inventory_id"`
+	ProjectID   types.String   `tfsdk:"project_id"`
+	Playbook    types.String   `tfsdk:"playbook"`
+	Forks       types.Int64    `tfsdk:"forks"`
+	Limit       types.String   `tfsdk:"limit"`
+	Verbosity   types.Int64    `tfsdk:"verbosity"`
+	ExtraVars   types.String   `tfsdk:"extra_vars"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+// jobTemplateAPIFields maps controller field names to schema attributes for validation errors.
+var jobTemplateAPIFields = map[string]string{
+	"name":        "name",
+	"description": "description",
+	"job_type":    "job_type",
+	"inventory":   "inventory_id",
+	"project":     "project_id",
+	"playbook":    "playbook",
+	"forks":       "forks",
+	"limit":       "limit",
+	"verbosity":   "verbosity",
+	"extra_vars":  "extra_vars",
 }
 
 func (r *JobTemplateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -67,8 +82,8 @@ func (r *JobTemplateResource) Schema(ctx context.Context, req resource.SchemaReq
 				MarkdownDescription: "Type of job: 'run' or 'check'.",
 			},
 			"inventory_id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "ID of the inventory to use.",
+				Optional:            true,
+				MarkdownDescription: "ID of the inventory to use. Leave unset to choose the inventory when the job is launched.",
 			},
 			"project_id": schema.StringAttribute{
 				Required:            true,
@@ -95,6 +110,14 @@ func (r *JobTemplateResource) Schema(ctx context.Context, req resource.SchemaReq
 				MarkdownDescription: "Extra variables in JSON/YAML format.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -119,6 +142,14 @@ func (r *JobTemplateResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	invID, _ := strconv.Atoi(data.InventoryID.ValueString())
 	projID, _ := strconv.Atoi(data.ProjectID.ValueString())
 
@@ -146,30 +177,23 @@ func (r *JobTemplateResource) Create(ctx context.Context, req resource.CreateReq
 		jt.ExtraVars = data.ExtraVars.ValueString()
 	}
 
-	createdJt, err := r.client.CreateJobTemplate(jt)
+	createdJt, err := r.client.JobTemplates.Create(ctx, jt)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create job template: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to create job template", err, jobTemplateAPIFields)
 		return
 	}
 
 	data.ID = types.StringValue(strconv.Itoa(createdJt.ID))
 	data.Name = types.StringValue(createdJt.Name)
 	data.JobType = types.StringValue(createdJt.JobType)
-	data.InventoryID = types.StringValue(strconv.Itoa(createdJt.Inventory))
+	data.InventoryID = idValue(createdJt.Inventory)
 	data.ProjectID = types.StringValue(strconv.Itoa(createdJt.Project))
 	data.Playbook = types.StringValue(createdJt.Playbook)
-	
-	if createdJt.Description != "" {
-		data.Description = types.StringValue(createdJt.Description)
-	}
-	data.Forks = types.Int64Value(int64(createdJt.Forks))
-	if createdJt.Limit != "" {
-		data.Limit = types.StringValue(createdJt.Limit)
-	}
-	data.Verbosity = types.Int64Value(int64(createdJt.Verbosity))
-	if createdJt.ExtraVars != "" {
-		data.ExtraVars = types.StringValue(createdJt.ExtraVars)
-	}
+	data.Description = stringValue(data.Description, createdJt.Description)
+	data.Forks = int64Value(data.Forks, createdJt.Forks)
+	data.Limit = stringValue(data.Limit, createdJt.Limit)
+	data.Verbosity = int64Value(data.Verbosity, createdJt.Verbosity)
+	data.ExtraVars = stringValue(data.ExtraVars, createdJt.ExtraVars)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -182,81 +206,87 @@ func (r *JobTemplateResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	id, _ := strconv.Atoi(data.ID.ValueString())
 
-	jt, err := r.client.GetJobTemplate(id)
+	jt, err := r.client.JobTemplates.Get(ctx, id)
 	if err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read job template: %s", err))
 		return
 	}
 
 	data.Name = types.StringValue(jt.Name)
-	data.Description = types.StringValue(jt.Description)
+	data.Description = stringValue(data.Description, jt.Description)
 	data.JobType = types.StringValue(jt.JobType)
-	data.InventoryID = types.StringValue(strconv.Itoa(jt.Inventory))
+	data.InventoryID = idValue(jt.Inventory)
 	data.ProjectID = types.StringValue(strconv.Itoa(jt.Project))
 	data.Playbook = types.StringValue(jt.Playbook)
-	data.Forks = types.Int64Value(int64(jt.Forks))
-	data.Limit = types.StringValue(jt.Limit)
-	data.Verbosity = types.Int64Value(int64(jt.Verbosity))
-	data.ExtraVars = types.StringValue(jt.ExtraVars)
+	data.Forks = int64Value(data.Forks, jt.Forks)
+	data.Limit = stringValue(data.Limit, jt.Limit)
+	data.Verbosity = int64Value(data.Verbosity, jt.Verbosity)
+	data.ExtraVars = stringValue(data.ExtraVars, jt.ExtraVars)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *JobTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data JobTemplateResourceModel
+	var data, state JobTemplateResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	id, _ := strconv.Atoi(data.ID.ValueString())
-	invID, _ := strconv.Atoi(data.InventoryID.ValueString())
-	projID, _ := strconv.Atoi(data.ProjectID.ValueString())
-
-	jt := &client.JobTemplate{
-		ID:        id,
-		Name:      data.Name.ValueString(),
-		JobType:   data.JobType.ValueString(),
-		Inventory: invID,
-		Project:   projID,
-		Playbook:  data.Playbook.ValueString(),
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
-	if !data.Description.IsNull() {
-		jt.Description = data.Description.ValueString()
-	}
-	if !data.Forks.IsNull() {
-		jt.Forks = int(data.Forks.ValueInt64())
-	}
-	if !data.Limit.IsNull() {
-		jt.Limit = data.Limit.ValueString()
-	}
-	if !data.Verbosity.IsNull() {
-		jt.Verbosity = int(data.Verbosity.ValueInt64())
-	}
-	if !data.ExtraVars.IsNull() {
-		jt.ExtraVars = data.ExtraVars.ValueString()
-	}
+	id, _ := strconv.Atoi(data.ID.ValueString())
 
-	updatedJt, err := r.client.UpdateJobTemplate(jt)
+	patch := patchBuilder{}
+	patch.String("name", data.Name, state.Name)
+	patch.String("description", data.Description, state.Description)
+	patch.String("job_type", data.JobType, state.JobType)
+	patch.ID("inventory", data.InventoryID, state.InventoryID)
+	patch.ID("project", data.ProjectID, state.ProjectID)
+	patch.String("playbook", data.Playbook, state.Playbook)
+	patch.Int64("forks", data.Forks, state.Forks)
+	patch.String("limit", data.Limit, state.Limit)
+	patch.Int64("verbosity", data.Verbosity, state.Verbosity)
+	patch.String("extra_vars", data.ExtraVars, state.ExtraVars)
+
+	updatedJt, err := r.client.JobTemplates.Patch(ctx, id, patch)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update job template: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to update job template", err, jobTemplateAPIFields)
 		return
 	}
 
 	data.Name = types.StringValue(updatedJt.Name)
-	data.Description = types.StringValue(updatedJt.Description)
+	data.Description = stringValue(data.Description, updatedJt.Description)
 	data.JobType = types.StringValue(updatedJt.JobType)
-	data.InventoryID = types.StringValue(strconv.Itoa(updatedJt.Inventory))
+	data.InventoryID = idValue(updatedJt.Inventory)
 	data.ProjectID = types.StringValue(strconv.Itoa(updatedJt.Project))
 	data.Playbook = types.StringValue(updatedJt.Playbook)
-	data.Forks = types.Int64Value(int64(updatedJt.Forks))
-	data.Limit = types.StringValue(updatedJt.Limit)
-	data.Verbosity = types.Int64Value(int64(updatedJt.Verbosity))
-	data.ExtraVars = types.StringValue(updatedJt.ExtraVars)
+	data.Forks = int64Value(data.Forks, updatedJt.Forks)
+	data.Limit = stringValue(data.Limit, updatedJt.Limit)
+	data.Verbosity = int64Value(data.Verbosity, updatedJt.Verbosity)
+	data.ExtraVars = stringValue(data.ExtraVars, updatedJt.ExtraVars)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -267,12 +297,25 @@ func (r *JobTemplateResource) Delete(ctx context.Context, req resource.DeleteReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	if err := r.client.DeleteJobTemplate(id); err != nil {
+	if err := r.client.JobTemplates.Delete(ctx, id); err != nil && !client.IsNotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete job template: %s", err))
 	}
 }
 
 func (r *JobTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importByIDOrName(ctx, r.client, req, resp, []string{"<name>"}, func(ctx context.Context, names []string) (int, error) {
+		return findID(r.client.JobTemplates.List(ctx, client.ListOptions{Name: names[0]}),
+			func(jt *client.JobTemplate) int { return jt.ID },
+			fmt.Sprintf("job template %q", names[0]))
+	})
 }
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccJobTemplateResource(t *testing.T) {
+	s := newTestServer(t)
+	var id int
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(s, "job_templates"),
+		Steps: []resource.TestStep{
+			// Create
+			{
+				Config: testAccProviderConfig(s) + testAccJobTemplateConfig("Deploy", `
+  description = "Deploy the site"
+  forks       = 10
+  limit       = "webservers"
+  verbosity   = 2
+  extra_vars  = "---\nversion: 1.0"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("aap_job_template.test", "id"),
+					resource.TestCheckResourceAttr("aap_job_template.test", "name", "Deploy"),
+					resource.TestCheckResourceAttr("aap_job_template.test", "job_type", "run"),
+					resource.TestCheckResourceAttrPair("aap_job_template.test", "inventory_id", "aap_inventory.test", "id"),
+					resource.TestCheckResourceAttrPair("aap_job_template.test", "project_id", "aap_project.test", "id"),
+					resource.TestCheckResourceAttr("aap_job_template.test", "playbook", "site.yml"),
+					resource.TestCheckResourceAttr("aap_job_template.test", "forks", "10"),
+					resource.TestCheckResourceAttr("aap_job_template.test", "verbosity", "2"),
+					testAccCaptureID("aap_job_template.test", &id),
+				),
+			},
+			// Import
+			{
+				ResourceName:            "aap_job_template.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Import by name
+			{
+				ResourceName:            "aap_job_template.test",
+				ImportState:             true,
+				ImportStateId:           "Deploy",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Update in place
+			{
+				Config: testAccProviderConfig(s) + testAccJobTemplateConfig("Deploy site", `
+  description = "Deploy everything"
+  forks       = 0
+  limit       = "all"
+  verbosity   = 0
+  extra_vars  = "---\nversion: 2.0"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aap_job_template.test", "name", "Deploy site"),
+					resource.TestCheckResourceAttr("aap_job_template.test", "forks", "0"),
+					resource.TestCheckResourceAttr("aap_job_template.test", "limit", "all"),
+					resource.TestCheckResourceAttr("aap_job_template.test", "verbosity", "0"),
+				),
+			},
+			// Clear optional attributes
+			{
+				Config: testAccProviderConfig(s) + testAccJobTemplateConfig("Deploy site", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("aap_job_template.test", "description"),
+					resource.TestCheckNoResourceAttr("aap_job_template.test", "limit"),
+					resource.TestCheckNoResourceAttr("aap_job_template.test", "extra_vars"),
+				),
+			},
+			// Deleted outside Terraform
+			{
+				PreConfig:          testAccDeleteOutOfBand(t, s, "job_templates", &id),
+				Config:             testAccProviderConfig(s) + testAccJobTemplateConfig("Deploy site", ""),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Recreate
+			{
+				Config: testAccProviderConfig(s) + testAccJobTemplateConfig("Deploy site", ""),
+				Check:  resource.TestCheckResourceAttr("aap_job_template.test", "name", "Deploy site"),
+			},
+		},
+	})
+}
+
+func testAccJobTemplateConfig(name, attrs string) string {
+	return testAccInventoryConfig("Job Inventory", "") + fmt.Sprintf(`
+resource "aap_project" "test" {
+  name            = "Job Project"
+  organization_id = aap_organization.test.id
+  scm_type        = "git"
+  scm_url         = "https://git.example.com/site.git"
+}
+
+resource "aap_job_template" "test" {
+  name         = %q
+  job_type     = "run"
+  inventory_id = aap_inventory.test.id
+  project_id   = aap_project.test.id
+  playbook     = "site.yml"
+%s}
+`, name, attrs)
+}
+
+func TestAccJobTemplateResource_promptInventory(t *testing.T) {
+	s := newTestServer(t)
+
+	config := testAccProviderConfig(s) + testAccOrganizationConfig("Job Org", "") + `
+resource "aap_project" "test" {
+  name            = "Job Project"
+  organization_id = aap_organization.test.id
+  scm_type        = "git"
+  scm_url         = "https://git.example.com/playbooks.git"
+}
+
+resource "aap_job_template" "test" {
+  name       = "Ad hoc"
+  job_type   = "run"
+  project_id = aap_project.test.id
+  playbook   = "site.yml"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(s, "job_templates"),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckNoResourceAttr("aap_job_template.test", "inventory_id"),
+			},
+			{
+				ResourceName:            "aap_job_template.test",
+				ImportState:             true,
+				ImportStateId:           "Ad hoc",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+		},
+	})
+}
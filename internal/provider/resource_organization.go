@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"strconv"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
@@ -28,11 +28,20 @@ type OrganizationResource struct {
 }
 
 type OrganizationResourceModel struct {
-	ID               types.String `tfsdk:"id"`
-	Name             types.String `tfsdk:"name"`
-	Description      types.String `tfsdk:"description"`
-	MaxHosts         types.Int64  `tfsdk:"max_hosts"`
-	CustomVirtualEnv types.String `tfsdk:"custom_virtualenv"`
+	ID               types.String   `tfsdk:"id"`
+	Name             types.String   `tfsdk:"name"`
+	Description      types.String   `tfsdk:"description"`
+	MaxHosts         types.Int64    `tfsdk:"max_hosts"`
+	CustomVirtualEnv types.String   `tfsdk:"custom_virtualenv"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+}
+
+// organizationAPIFields maps controller field names to schema attributes for validation errors.
+var organizationAPIFields = map[string]string{
+	"name":              "name",
+	"description":       "description",
+	"max_hosts":         "max_hosts",
+	"custom_virtualenv": "custom_virtualenv",
 }
 
 func (r *OrganizationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -71,6 +80,14 @@ func (r *OrganizationResource) Schema(ctx context.Context, req resource.SchemaRe
 				MarkdownDescription: "Local absolute file path containing a custom Python virtualenv to use.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -101,6 +118,14 @@ func (r *OrganizationResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	org := &client.Organization{
 		Name: data.Name.ValueString(),
 	}
@@ -115,21 +140,18 @@ func (r *OrganizationResource) Create(ctx context.Context, req resource.CreateRe
 		org.CustomVirtualEnv = data.CustomVirtualEnv.ValueString()
 	}
 
-	createdOrg, err := r.client.CreateOrganization(org)
+	createdOrg, err := r.client.Organizations.Create(ctx, org)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf("Unable to create organization, got error: %s", err),
-		)
+		addClientError(&resp.Diagnostics, "Unable to create organization", err, organizationAPIFields)
 		return
 	}
 
 	data.ID = types.StringValue(strconv.Itoa(createdOrg.ID))
 	// Re-hydrate other fields in case backend modified them
 	data.Name = types.StringValue(createdOrg.Name)
-	data.Description = types.StringValue(createdOrg.Description)
-	data.MaxHosts = types.Int64Value(int64(createdOrg.MaxHosts))
-	data.CustomVirtualEnv = types.StringValue(createdOrg.CustomVirtualEnv)
+	data.Description = stringValue(data.Description, createdOrg.Description)
+	data.MaxHosts = int64Value(data.MaxHosts, createdOrg.MaxHosts)
+	data.CustomVirtualEnv = stringValue(data.CustomVirtualEnv, createdOrg.CustomVirtualEnv)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -142,14 +164,26 @@ func (r *OrganizationResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	id, err := strconv.Atoi(data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid ID conversion", err.Error())
 		return
 	}
 
-	org, err := r.client.GetOrganization(id)
+	org, err := r.client.Organizations.Get(ctx, id)
 	if err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to read organization, got error: %s", err),
@@ -158,55 +192,52 @@ func (r *OrganizationResource) Read(ctx context.Context, req resource.ReadReques
 	}
 
 	data.Name = types.StringValue(org.Name)
-	data.Description = types.StringValue(org.Description)
-	data.MaxHosts = types.Int64Value(int64(org.MaxHosts))
-	data.CustomVirtualEnv = types.StringValue(org.CustomVirtualEnv)
+	data.Description = stringValue(data.Description, org.Description)
+	data.MaxHosts = int64Value(data.MaxHosts, org.MaxHosts)
+	data.CustomVirtualEnv = stringValue(data.CustomVirtualEnv, org.CustomVirtualEnv)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *OrganizationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data OrganizationResourceModel
+	var data, state OrganizationResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	id, err := strconv.Atoi(data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid ID conversion", err.Error())
 		return
 	}
 
-	org := &client.Organization{
-		ID:   id,
-		Name: data.Name.ValueString(),
-	}
-
-	if !data.Description.IsNull() {
-		org.Description = data.Description.ValueString()
-	}
-	if !data.MaxHosts.IsNull() {
-		org.MaxHosts = int(data.MaxHosts.ValueInt64())
-	}
-	if !data.CustomVirtualEnv.IsNull() {
-		org.CustomVirtualEnv = data.CustomVirtualEnv.ValueString()
-	}
+	patch := patchBuilder{}
+	patch.String("name", data.Name, state.Name)
+	patch.String("description", data.Description, state.Description)
+	patch.Int64("max_hosts", data.MaxHosts, state.MaxHosts)
+	patch.String("custom_virtualenv", data.CustomVirtualEnv, state.CustomVirtualEnv)
 
-	updatedOrg, err := r.client.UpdateOrganization(org)
+	updatedOrg, err := r.client.Organizations.Patch(ctx, id, patch)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf("Unable to update organization, got error: %s", err),
-		)
+		addClientError(&resp.Diagnostics, "Unable to update organization", err, organizationAPIFields)
 		return
 	}
 
 	data.Name = types.StringValue(updatedOrg.Name)
-	data.Description = types.StringValue(updatedOrg.Description)
-	data.MaxHosts = types.Int64Value(int64(updatedOrg.MaxHosts))
-	data.CustomVirtualEnv = types.StringValue(updatedOrg.CustomVirtualEnv)
+	data.Description = stringValue(data.Description, updatedOrg.Description)
+	data.MaxHosts = int64Value(data.MaxHosts, updatedOrg.MaxHosts)
+	data.CustomVirtualEnv = stringValue(data.CustomVirtualEnv, updatedOrg.CustomVirtualEnv)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -219,14 +250,22 @@ func (r *OrganizationResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	id, err := strconv.Atoi(data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid ID conversion", err.Error())
 		return
 	}
 
-	err = r.client.DeleteOrganization(id)
-	if err != nil {
+	err = r.client.Organizations.Delete(ctx, id)
+	if err != nil && !client.IsNotFound(err) {
 		resp.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to delete organization, got error: %s", err),
@@ -236,5 +275,9 @@ func (r *OrganizationResource) Delete(ctx context.Context, req resource.DeleteRe
 }
 
 func (r *OrganizationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importByIDOrName(ctx, r.client, req, resp, []string{"<name>"}, func(ctx context.Context, names []string) (int, error) {
+		return findID(r.client.Organizations.List(ctx, client.ListOptions{Name: names[0]}),
+			func(o *client.Organization) int { return o.ID },
+			fmt.Sprintf("organization %q", names[0]))
+	})
 }
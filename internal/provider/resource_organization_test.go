@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccOrganizationResource(t *testing.T) {
+	s := newTestServer(t)
+	var id int
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(s, "organizations"),
+		Steps: []resource.TestStep{
+			// Create
+			{
+				Config: testAccProviderConfig(s) + testAccOrganizationConfig("Engineering", `
+  description       = "Engineering teams"
+  max_hosts         = 100
+  custom_virtualenv = "/var/lib/awx/venv/custom"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("aap_organization.test", "id"),
+					resource.TestCheckResourceAttr("aap_organization.test", "name", "Engineering"),
+					resource.TestCheckResourceAttr("aap_organization.test", "description", "Engineering teams"),
+					resource.TestCheckResourceAttr("aap_organization.test", "max_hosts", "100"),
+					resource.TestCheckResourceAttr("aap_organization.test", "custom_virtualenv", "/var/lib/awx/venv/custom"),
+					testAccCaptureID("aap_organization.test", &id),
+				),
+			},
+			// Import
+			{
+				ResourceName:            "aap_organization.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Import by name
+			{
+				ResourceName:            "aap_organization.test",
+				ImportState:             true,
+				ImportStateId:           "Engineering",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			{
+				ResourceName:  "aap_organization.test",
+				ImportState:   true,
+				ImportStateId: "Marketing",
+				ExpectError:   regexp.MustCompile(`no organization "Marketing" found`),
+			},
+			{
+				ResourceName:  "aap_organization.test",
+				ImportState:   true,
+				ImportStateId: "Engineering/",
+				ExpectError:   regexp.MustCompile(`Expected a numeric ID or <name>`),
+			},
+			// Update in place
+			{
+				Config: testAccProviderConfig(s) + testAccOrganizationConfig("Engineering Org", `
+  description       = "All engineering"
+  max_hosts         = 0
+  custom_virtualenv = "/var/lib/awx/venv/custom"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aap_organization.test", "name", "Engineering Org"),
+					resource.TestCheckResourceAttr("aap_organization.test", "description", "All engineering"),
+					resource.TestCheckResourceAttr("aap_organization.test", "max_hosts", "0"),
+					resource.TestCheckResourceAttrWith("aap_organization.test", "id", func(v string) error {
+						if v != fmt.Sprint(id) {
+							return fmt.Errorf("expected in-place update of %d, got %s", id, v)
+						}
+						return nil
+					}),
+				),
+			},
+			// Clear optional attributes
+			{
+				Config: testAccProviderConfig(s) + testAccOrganizationConfig("Engineering Org", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("aap_organization.test", "description"),
+					resource.TestCheckNoResourceAttr("aap_organization.test", "custom_virtualenv"),
+				),
+			},
+			// Deleted outside Terraform
+			{
+				PreConfig:          testAccDeleteOutOfBand(t, s, "organizations", &id),
+				Config:             testAccProviderConfig(s) + testAccOrganizationConfig("Engineering Org", ""),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Recreate
+			{
+				Config: testAccProviderConfig(s) + testAccOrganizationConfig("Engineering Org", ""),
+				Check:  resource.TestCheckResourceAttr("aap_organization.test", "name", "Engineering Org"),
+			},
+		},
+	})
+}
+
+func testAccOrganizationConfig(name, attrs string) string {
+	return fmt.Sprintf(`
+resource "aap_organization" "test" {
+  name = %q
+%s}
+`, name, attrs)
+}
@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"strconv"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -26,19 +26,36 @@ type ProjectResource struct {
 }
 
 type ProjectResourceModel struct {
-	ID                    types.String `tfsdk:"id"`
-	Name                  types.String `tfsdk:"name"`
-	Description           types.String `tfsdk:"description"`
-	OrganizationID        types.String `tfsdk:"organization_id"`
-	ScmType               types.String `tfsdk:"scm_type"`
-	ScmUrl                types.String `tfsdk:"scm_url"`
-	ScmBranch             types.String `tfsdk:"scm_branch"`
-	ScmCredentialID       types.String `tfsdk:"scm_credential_id"`
-	ScmClean              types.Bool   `tfsdk:"scm_clean"`
-	ScmDeleteOnUpdate     types.Bool   `tfsdk:"scm_delete_on_update"`
-	ScmUpdateOnLaunch     types.Bool   `tfsdk:"scm_update_on_launch"`
-	ScmUpdateCacheTimeout types.Int64  `tfsdk:"scm_update_cache_timeout"`
-	LocalPath             types.String `tfsdk:"local_path"`
+	ID                    types.String   `tfsdk:"id"`
+	Name                  types.String   `tfsdk:"name"`
+	Description           types.String   `tfsdk:"description"`
+	OrganizationID        types.String   `tfsdk:"organization_id"`
+	ScmType               types.String   `tfsdk:"scm_type"`
+	ScmUrl                types.String   `tfsdk:"scm_url"`
+	ScmBranch             types.String   `tfsdk:"scm_branch"`
+	ScmCredentialID       types.String   `tfsdk:"scm_credential_id"`
+	ScmClean              types.Bool     `tfsdk:"scm_clean"`
+	ScmDeleteOnUpdate     types.Bool     `tfsdk:"scm_delete_on_update"`
+	ScmUpdateOnLaunch     types.Bool     `tfsdk:"scm_update_on_launch"`
+	ScmUpdateCacheTimeout types.Int64    `tfsdk:"scm_update_cache_timeout"`
+	LocalPath             types.String   `tfsdk:"local_path"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
+}
+
+// projectAPIFields maps controller field names to schema attributes for validation errors.
+var projectAPIFields = map[string]string{
+	"name":                     "name",
+	"description":              "description",
+	"organization":             "organization_id",
+	"scm_type":                 "scm_type",
+	"scm_url":                  "scm_url",
+	"scm_branch":               "scm_branch",
+	"credential":               "scm_credential_id",
+	"scm_clean":                "scm_clean",
+	"scm_delete_on_update":     "scm_delete_on_update",
+	"scm_update_on_launch":     "scm_update_on_launch",
+	"scm_update_cache_timeout": "scm_update_cache_timeout",
+	"local_path":               "local_path",
 }
 
 func (r *ProjectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -101,9 +118,21 @@ func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest
 			},
 			"local_path": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Local path for manual projects.",
+				Computed:            true,
+				MarkdownDescription: "Local path for manual projects. The controller generates it for SCM projects.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -126,6 +155,14 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	orgID, _ := strconv.Atoi(data.OrganizationID.ValueString())
 	credID, _ := strconv.Atoi(data.ScmCredentialID.ValueString())
 
@@ -158,17 +195,18 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 	if !data.ScmUpdateCacheTimeout.IsNull() {
 		p.ScmUpdateCacheTimeout = int(data.ScmUpdateCacheTimeout.ValueInt64())
 	}
-	if !data.LocalPath.IsNull() {
+	if !data.LocalPath.IsUnknown() {
 		p.LocalPath = data.LocalPath.ValueString()
 	}
 
-	created, err := r.client.CreateProject(p)
+	created, err := r.client.Projects.Create(ctx, p)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create project: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to create project", err, projectAPIFields)
 		return
 	}
 
 	data.ID = types.StringValue(strconv.Itoa(created.ID))
+	data.LocalPath = types.StringValue(created.LocalPath)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -179,78 +217,81 @@ func (r *ProjectResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	p, err := r.client.GetProject(id)
+	p, err := r.client.Projects.Get(ctx, id)
 	if err != nil {
+		if client.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read project: %s", err))
 		return
 	}
 
 	data.Name = types.StringValue(p.Name)
-	data.Description = types.StringValue(p.Description)
+	data.Description = stringValue(data.Description, p.Description)
 	data.OrganizationID = types.StringValue(strconv.Itoa(p.Organization))
 	data.ScmType = types.StringValue(p.ScmType)
-	data.ScmUrl = types.StringValue(p.ScmUrl)
-	data.ScmBranch = types.StringValue(p.ScmBranch)
-	if p.ScmCredential > 0 {
-		data.ScmCredentialID = types.StringValue(strconv.Itoa(p.ScmCredential))
-	}
-	data.ScmClean = types.BoolValue(p.ScmClean)
-	data.ScmDeleteOnUpdate = types.BoolValue(p.ScmDeleteOnUpdate)
-	data.ScmUpdateOnLaunch = types.BoolValue(p.ScmUpdateOnLaunch)
-	data.ScmUpdateCacheTimeout = types.Int64Value(int64(p.ScmUpdateCacheTimeout))
+	data.ScmUrl = stringValue(data.ScmUrl, p.ScmUrl)
+	data.ScmBranch = stringValue(data.ScmBranch, p.ScmBranch)
+	data.ScmCredentialID = idValue(p.ScmCredential)
+	data.ScmClean = boolValue(data.ScmClean, p.ScmClean)
+	data.ScmDeleteOnUpdate = boolValue(data.ScmDeleteOnUpdate, p.ScmDeleteOnUpdate)
+	data.ScmUpdateOnLaunch = boolValue(data.ScmUpdateOnLaunch, p.ScmUpdateOnLaunch)
+	data.ScmUpdateCacheTimeout = int64Value(data.ScmUpdateCacheTimeout, p.ScmUpdateCacheTimeout)
 	data.LocalPath = types.StringValue(p.LocalPath)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ProjectResourceModel
+	var data, state ProjectResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	orgID, _ := strconv.Atoi(data.OrganizationID.ValueString())
-	credID, _ := strconv.Atoi(data.ScmCredentialID.ValueString())
 
-	p := &client.Project{
-		ID:           id,
-		Name:         data.Name.ValueString(),
-		Organization: orgID,
-		ScmType:      data.ScmType.ValueString(),
-	}
-	if !data.Description.IsNull() {
-		p.Description = data.Description.ValueString()
-	}
-	if !data.ScmUrl.IsNull() {
-		p.ScmUrl = data.ScmUrl.ValueString()
-	}
-	if !data.ScmBranch.IsNull() {
-		p.ScmBranch = data.ScmBranch.ValueString()
-	}
-	if credID > 0 {
-		p.ScmCredential = credID
-	}
-	if !data.ScmClean.IsNull() {
-		p.ScmClean = data.ScmClean.ValueBool()
-	}
-	if !data.ScmDeleteOnUpdate.IsNull() {
-		p.ScmDeleteOnUpdate = data.ScmDeleteOnUpdate.ValueBool()
-	}
-	if !data.ScmUpdateOnLaunch.IsNull() {
-		p.ScmUpdateOnLaunch = data.ScmUpdateOnLaunch.ValueBool()
-	}
-	if !data.ScmUpdateCacheTimeout.IsNull() {
-		p.ScmUpdateCacheTimeout = int(data.ScmUpdateCacheTimeout.ValueInt64())
-	}
+	patch := patchBuilder{}
+	patch.String("name", data.Name, state.Name)
+	patch.String("description", data.Description, state.Description)
+	patch.ID("organization", data.OrganizationID, state.OrganizationID)
+	patch.String("scm_type", data.ScmType, state.ScmType)
+	patch.String("scm_url", data.ScmUrl, state.ScmUrl)
+	patch.String("scm_branch", data.ScmBranch, state.ScmBranch)
+	patch.ID("credential", data.ScmCredentialID, state.ScmCredentialID)
+	patch.Bool("scm_clean", data.ScmClean, state.ScmClean)
+	patch.Bool("scm_delete_on_update", data.ScmDeleteOnUpdate, state.ScmDeleteOnUpdate)
+	patch.Bool("scm_update_on_launch", data.ScmUpdateOnLaunch, state.ScmUpdateOnLaunch)
+	patch.Int64("scm_update_cache_timeout", data.ScmUpdateCacheTimeout, state.ScmUpdateCacheTimeout)
+	patch.String("local_path", data.LocalPath, state.LocalPath)
 
-	_, err := r.client.UpdateProject(p)
+	updated, err := r.client.Projects.Patch(ctx, id, patch)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update project: %s", err))
+		addClientError(&resp.Diagnostics, "Unable to update project", err, projectAPIFields)
 		return
 	}
+	if data.LocalPath.IsUnknown() {
+		data.LocalPath = types.StringValue(updated.LocalPath)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -261,12 +302,25 @@ func (r *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	id, _ := strconv.Atoi(data.ID.ValueString())
-	if err := r.client.DeleteProject(id); err != nil {
+	if err := r.client.Projects.Delete(ctx, id); err != nil && !client.IsNotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete project: %s", err))
 	}
 }
 
 func (r *ProjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importByIDOrName(ctx, r.client, req, resp, []string{"<organization>/<name>"}, func(ctx context.Context, names []string) (int, error) {
+		return findID(r.client.Projects.List(ctx, client.ListOptions{OrganizationName: names[0], Name: names[1]}),
+			func(p *client.Project) int { return p.ID },
+			fmt.Sprintf("project %q in organization %q", names[1], names[0]))
+	})
 }
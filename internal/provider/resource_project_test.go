@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccProjectResource(t *testing.T) {
+	s := newTestServer(t)
+	var id int
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroyed(s, "projects"),
+		Steps: []resource.TestStep{
+			// Create
+			{
+				Config: testAccProviderConfig(s) + testAccProjectConfig("Playbooks", `
+  description              = "Site playbooks"
+  scm_branch               = "main"
+  scm_credential_id        = aap_credential_scm.test.id
+  scm_clean                = true
+  scm_delete_on_update     = true
+  scm_update_on_launch     = true
+  scm_update_cache_timeout = 300
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("aap_project.test", "id"),
+					resource.TestCheckResourceAttr("aap_project.test", "name", "Playbooks"),
+					resource.TestCheckResourceAttr("aap_project.test", "scm_type", "git"),
+					resource.TestCheckResourceAttr("aap_project.test", "scm_url", "https://git.example.com/site.git"),
+					resource.TestCheckResourceAttr("aap_project.test", "scm_branch", "main"),
+					resource.TestCheckResourceAttrPair("aap_project.test", "scm_credential_id", "aap_credential_scm.test", "id"),
+					resource.TestCheckResourceAttr("aap_project.test", "scm_clean", "true"),
+					resource.TestCheckResourceAttr("aap_project.test", "scm_update_cache_timeout", "300"),
+					resource.TestMatchResourceAttr("aap_project.test", "local_path", regexp.MustCompile(`^_\d+__playbooks$`)),
+					testAccCaptureID("aap_project.test", &id),
+				),
+			},
+			// Import
+			{
+				ResourceName:            "aap_project.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Import by name
+			{
+				ResourceName:            "aap_project.test",
+				ImportState:             true,
+				ImportStateId:           "Project Org/Playbooks",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Update in place
+			{
+				Config: testAccProviderConfig(s) + testAccProjectConfig("Site Playbooks", `
+  description              = "All site playbooks"
+  scm_branch               = "release"
+  scm_credential_id        = aap_credential_scm.test.id
+  scm_clean                = false
+  scm_delete_on_update     = true
+  scm_update_on_launch     = true
+  scm_update_cache_timeout = 0
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aap_project.test", "name", "Site Playbooks"),
+					resource.TestCheckResourceAttr("aap_project.test", "scm_branch", "release"),
+					// The generated path survives renames.
+					resource.TestMatchResourceAttr("aap_project.test", "local_path", regexp.MustCompile(`^_\d+__playbooks$`)),
+					resource.TestCheckResourceAttr("aap_project.test", "scm_clean", "false"),
+					resource.TestCheckResourceAttr("aap_project.test", "scm_update_cache_timeout", "0"),
+				),
+			},
+			// Clear optional attributes
+			{
+				Config: testAccProviderConfig(s) + testAccProjectConfig("Site Playbooks", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("aap_project.test", "description"),
+					resource.TestCheckNoResourceAttr("aap_project.test", "scm_branch"),
+					resource.TestCheckNoResourceAttr("aap_project.test", "scm_credential_id"),
+					resource.TestCheckNoResourceAttr("aap_project.test", "scm_update_on_launch"),
+				),
+			},
+			// Deleted outside Terraform
+			{
+				PreConfig:          testAccDeleteOutOfBand(t, s, "projects", &id),
+				Config:             testAccProviderConfig(s) + testAccProjectConfig("Site Playbooks", ""),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Recreate
+			{
+				Config: testAccProviderConfig(s) + testAccProjectConfig("Site Playbooks", ""),
+				Check:  resource.TestCheckResourceAttr("aap_project.test", "name", "Site Playbooks"),
+			},
+		},
+	})
+}
+
+func testAccProjectConfig(name, attrs string) string {
+	return testAccOrganizationConfig("Project Org", "") + fmt.Sprintf(`
+resource "aap_credential_scm" "test" {
+  name            = "Git"
+  organization_id = aap_organization.test.id
+  username        = "git"
+  password        = "git-token"
+}
+
+resource "aap_project" "test" {
+  name            = %q
+  organization_id = aap_organization.test.id
+  scm_type        = "git"
+  scm_url         = "https://git.example.com/site.git"
+%s}
+`, name, attrs)
+}
@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// secretsKey is the private state key holding the secretsRecord of a
+// credential.
+const secretsKey = "secrets"
+
+// secretVersionDescription documents the secret_version attribute of
+// credential resources.
+const secretVersionDescription = "Arbitrary value, such as the date of the last rotation. Changing it sends the secrets, including write-only ones, to the controller again even if they are unchanged. Required when write-only secrets are set."
+
+// secretsRecord remembers the secret inputs Terraform last sent to the
+// controller, as a salted hash, and the credential's modified timestamp
+// after sending them. The controller never returns secrets, so Read uses it
+// to tell whether the secrets in state still describe the credential.
+// Nothing derived from write-only secrets is recorded, since private state
+// is stored with the rest of the state.
+type secretsRecord struct {
+	Salt string `json:"salt"`
+	Hash string `json:"hash"`
+	// WriteOnly is set if write-only secrets were sent.
+	WriteOnly bool   `json:"write_only,omitempty"`
+	Modified  string `json:"modified"`
+}
+
+// privateData is the private state of a resource.
+type privateData interface {
+	GetKey(context.Context, string) ([]byte, diag.Diagnostics)
+	SetKey(context.Context, string, []byte) diag.Diagnostics
+}
+
+// hashSecrets hashes secrets, keyed by input, with salt.
+func hashSecrets(salt string, secrets map[string]string) string {
+	// Maps marshal with sorted keys, so equal secrets hash equally.
+	b, _ := json.Marshal(secrets)
+	sum := sha256.Sum256(append([]byte(salt), b...))
+	return hex.EncodeToString(sum[:])
+}
+
+// getSecretsRecord returns the secrets record in private, or nil if there
+// is none.
+func getSecretsRecord(ctx context.Context, private privateData, diags *diag.Diagnostics) *secretsRecord {
+	b, d := private.GetKey(ctx, secretsKey)
+	diags.Append(d...)
+	var rec secretsRecord
+	if len(b) == 0 || json.Unmarshal(b, &rec) != nil {
+		return nil
+	}
+	return &rec
+}
+
+// setSecretsRecord records in private state that secrets were sent to the
+// controller, which then reported the credential as modified at modified.
+// writeOnly holds the write-only secrets that were sent with them, or is nil
+// if those were not sent, in which case whether they last were is kept.
+func setSecretsRecord(ctx context.Context, private privateData, secrets, writeOnly map[string]string, modified string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	rec := secretsRecord{Modified: modified, WriteOnly: len(writeOnly) > 0}
+	if old := getSecretsRecord(ctx, private, &diags); old != nil && writeOnly == nil {
+		rec.WriteOnly = old.WriteOnly
+	}
+	salt := make([]byte, 16)
+	rand.Read(salt)
+	rec.Salt = hex.EncodeToString(salt)
+	rec.Hash = hashSecrets(rec.Salt, secrets)
+	b, _ := json.Marshal(rec)
+	diags.Append(private.SetKey(ctx, secretsKey, b)...)
+	return diags
+}
+
+// secretsDrifted reports whether the secrets in state can no longer be
+// trusted: the credential was modified since Terraform last sent its
+// secrets, for instance to rotate a password in the UI, or the secrets in
+// state are not those that were sent. It warns when it returns true.
+// Credentials without a record, such as imported ones, never drift.
+func secretsDrifted(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse, name string, secrets map[string]string, modified string) bool {
+	rec := getSecretsRecord(ctx, req.Private, &resp.Diagnostics)
+	if rec == nil || (len(secrets) == 0 && !rec.WriteOnly) {
+		return false
+	}
+	if rec.Modified == modified && rec.Hash == hashSecrets(rec.Salt, secrets) {
+		return false
+	}
+	resp.Diagnostics.AddWarning(
+		"Credential Modified Outside Terraform",
+		fmt.Sprintf("Credential %q was modified since Terraform last set its secrets. The controller never returns "+
+			"secret values, so they may have been changed; Terraform will send the configured values again on the "+
+			"next apply.", name),
+	)
+	return true
+}
+
+// writeOnlyDescription documents the write-only counterpart of the secret
+// attribute name.
+func writeOnlyDescription(name string) string {
+	return fmt.Sprintf("Write-only alternative to `%s`: sent to the controller but never stored in Terraform state. "+
+		"Requires Terraform 1.11 or later, and `secret_version`.", name)
+}
+
+// validateWriteOnlySecrets checks the write-only counterparts of secret
+// attributes, keyed by attribute name: at most one of each pair may be set,
+// and write-only secrets need secret_version, since changing it is the only
+// way to have them sent again.
+func validateWriteOnlySecrets(diags *diag.Diagnostics, pairs map[string][2]types.String, version types.String) {
+	names := slices.Sorted(maps.Keys(pairs))
+	writeOnly := false
+	for _, name := range names {
+		v, wo := pairs[name][0], pairs[name][1]
+		if wo.IsNull() {
+			continue
+		}
+		writeOnly = true
+		if !v.IsNull() {
+			diags.AddAttributeError(path.Root(name+"_wo"), "Invalid Attribute Combination",
+				fmt.Sprintf("Only one of %s and %s_wo may be set.", name, name))
+		}
+	}
+	if writeOnly && version.IsNull() {
+		diags.AddAttributeError(path.Root("secret_version"), "Missing Attribute Configuration",
+			"secret_version must be set when write-only secrets are used. Terraform only sends write-only secrets "+
+				"when the credential is created or secret_version changes.")
+	}
+}
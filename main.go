@@ -9,6 +9,9 @@ import (
 	"github.com/dhikrahashim/terraform-provider-aap/internal/provider"
 )
 
+// version is set at build time by goreleaser.
+var version = "dev"
+
 func main() {
 	var debug bool
 
@@ -20,7 +23,13 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New("0.1.0"), opts)
+	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+
+	// Revoke any OAuth2 tokens acquired while serving. Close gives up
+	// before go-plugin kills the process.
+	if closeErr := provider.Close(context.Background()); closeErr != nil {
+		log.Printf("[WARN] %s", closeErr)
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())